@@ -21,6 +21,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/kvitebjorn/idleinferno/internal/auth"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
 	"github.com/kvitebjorn/idleinferno/internal/requests"
 )
 
@@ -240,12 +241,15 @@ func (c *Client) handleSignUp() {
 			continue
 		}
 
+		fmt.Println("Choose your sin:")
+		for _, class := range model.Classes {
+			fmt.Printf("  %s: %s\n", class.Name, class.Description)
+		}
 		fmt.Print("class: ")
 		rawClass, _ := reader.ReadString('\n')
-		trimmedClass := strings.TrimSpace(rawClass)
-		isValid = checkInput(trimmedClass)
-		if !isValid {
-			fmt.Println("Invalid class, alphanumeric chars only allowed.")
+		trimmedClass, err := model.ValidateClass(strings.TrimSpace(rawClass))
+		if err != nil {
+			fmt.Println(err.Error())
 			continue
 		}
 
@@ -292,13 +296,23 @@ func (c *Client) handleInfo() {
 		return
 	}
 
+	if maybePlayer.Title != "" {
+		fmt.Println("title:", maybePlayer.Title)
+	}
 	fmt.Println("class:", maybePlayer.Class)
-	fmt.Println("xp:", maybePlayer.Xp)
 	fmt.Println("level:", maybePlayer.Level)
+	fmt.Println("next level:", maybePlayer.NextLevel)
 	fmt.Println("item level:", maybePlayer.ItemLevel)
+	if len(maybePlayer.Stash) > 0 {
+		fmt.Println("stash:", strings.Join(maybePlayer.Stash, ", "))
+	}
+	if len(maybePlayer.Sets) > 0 {
+		fmt.Println("sets:", strings.Join(maybePlayer.Sets, ", "))
+	}
 	fmt.Println("coordinates:", "(", maybePlayer.X, ",", maybePlayer.Y, ")")
 	fmt.Println("created:", maybePlayer.Created)
 	fmt.Println("online:", maybePlayer.Online)
+	fmt.Println("achievements:", strings.Join(maybePlayer.Achievements, ", "))
 }
 
 func (c *Client) handleQuit() {
@@ -460,7 +474,8 @@ func (c *Client) createUser(user *requests.User) error {
 	}
 
 	if res.StatusCode != 200 {
-		return err
+		body, _ := io.ReadAll(res.Body)
+		return errors.New(strings.TrimSpace(string(body)))
 	}
 
 	return nil
@@ -478,7 +493,7 @@ func (c *Client) handleInput() {
 
 	for {
 		// Print the input prompt
-		fmt.Print("[map|info] → ")
+		fmt.Print("[map|info|history|trade|stash|craft|boss] → ")
 		input, _ := reader.ReadString('\n')
 
 		c.mut.Lock()
@@ -525,7 +540,7 @@ func (c *Client) listen() {
 			fmt.Print("\033[2K\r")
 
 			// Reprint the input prompt and the current user input
-			fmt.Print("[map|info] → ")
+			fmt.Print("[map|info|history|trade|stash|craft|boss] → ")
 			c.mut.Lock()
 			fmt.Print(c.userInput) // Make sure we're printing the current input buffer
 			c.mut.Unlock()
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+func dashboardHandler() http.Handler {
+	files, err := fs.Sub(dashboardFiles, "dashboard")
+	if err != nil {
+		panic("Error loading dashboard: " + err.Error())
+	}
+	return http.StripPrefix("/dashboard/", http.FileServer(http.FS(files)))
+}
+
+func home(w http.ResponseWriter, r *http.Request) {
+	http.ServeFileFS(w, r, dashboardFiles, "dashboard/index.html")
+}
@@ -0,0 +1,168 @@
+"use strict";
+
+const CIRCLES = [
+  "Limbo", "Lust", "Gluttony", "Greed", "Wrath",
+  "Heresy", "Violence", "Fraud", "Treachery",
+];
+const WORLD_SIZE = 9;
+const MAX_EVENTS = 100;
+const SVG_NS = "http://www.w3.org/2000/svg";
+
+function el(tag, text) {
+  const node = document.createElement(tag);
+  if (text !== undefined) {
+    node.textContent = text;
+  }
+  return node;
+}
+
+function playerLink(name) {
+  const a = el("a", name);
+  a.href = "#/player/" + encodeURIComponent(name);
+  return a;
+}
+
+function row(cells) {
+  const tr = el("tr");
+  for (const cell of cells) {
+    const td = el("td");
+    td.append(cell);
+    tr.append(td);
+  }
+  return tr;
+}
+
+function eventItem(e) {
+  const li = el("li");
+  const time = el("span", new Date(e.time).toLocaleTimeString());
+  time.className = "time";
+  li.append(time, e.message);
+  return li;
+}
+
+function drawMap(players, widths) {
+  const svg = document.getElementById("map");
+  svg.replaceChildren();
+
+  // Circle 1 is the outermost ring, circle 9 the innermost
+  const ringWidth = 190 / WORLD_SIZE;
+  for (let i = 0; i < WORLD_SIZE; i++) {
+    const ring = document.createElementNS(SVG_NS, "circle");
+    ring.setAttribute("class", "ring");
+    ring.setAttribute("r", 190 - i * ringWidth);
+    svg.append(ring);
+
+    const label = document.createElementNS(SVG_NS, "text");
+    label.setAttribute("x", -8);
+    label.setAttribute("y", -(190 - i * ringWidth) + 9);
+    label.textContent = i + 1;
+    svg.append(label);
+  }
+
+  for (const p of players) {
+    const radius = 190 - (p.y + 0.5) * ringWidth;
+    const angle = (p.x / (widths[p.y] || WORLD_SIZE)) * 2 * Math.PI;
+    const dot = document.createElementNS(SVG_NS, "circle");
+    dot.setAttribute("class", "sinner");
+    dot.setAttribute("r", 4);
+    dot.setAttribute("cx", Math.cos(angle) * radius);
+    dot.setAttribute("cy", Math.sin(angle) * radius);
+    const title = document.createElementNS(SVG_NS, "title");
+    title.textContent = `${p.name} the level ${p.level} ${p.class} (${p.itemLevel})`;
+    dot.append(title);
+    svg.append(dot);
+  }
+}
+
+function showSnapshot(snapshot) {
+  drawMap(snapshot.players, snapshot.widths || []);
+
+  const tbody = document.querySelector("#online tbody");
+  tbody.replaceChildren(...snapshot.players
+    .slice()
+    .sort((a, b) => b.level - a.level)
+    .map(p => row([playerLink(p.name), p.class, p.level, p.itemLevel, CIRCLES[p.y]])));
+}
+
+async function loadLeaderboard() {
+  const res = await fetch("/players");
+  if (!res.ok) {
+    return;
+  }
+  const players = await res.json();
+  const tbody = document.querySelector("#leaderboard tbody");
+  tbody.replaceChildren(...players.map((p, i) =>
+    row([i + 1, playerLink(p.Name), p.Class, p.Level, p.ItemLevel])));
+}
+
+function addEvent(e) {
+  document.getElementById("events").prepend(eventItem(e));
+
+  const list = document.getElementById("events");
+  while (list.children.length > MAX_EVENTS) {
+    list.lastChild.remove();
+  }
+  if (e.kind === "levelup") {
+    loadLeaderboard();
+  }
+}
+
+async function showPlayer(name) {
+  document.getElementById("player-name").textContent = name;
+  const info = document.getElementById("player-info");
+  info.replaceChildren();
+
+  const res = await fetch("/player/" + encodeURIComponent(name));
+  const text = res.ok ? await res.text() : "";
+  if (text.trim() === "") {
+    info.append(el("dd", "Can't find that player."));
+    return;
+  }
+  const p = JSON.parse(text);
+  const fields = [
+    ["Title", p.Title || "none yet"],
+    ["Class", p.Class],
+    ["Level", p.Level],
+    ["Next level", p.NextLevel],
+    ["Item level", p.ItemLevel],
+    ["Sets", (p.Sets || []).join(", ") || "none yet"],
+    ["Circle", CIRCLES[p.Y]],
+    ["Coordinates", `(${p.X}, ${p.Y})`],
+    ["Online", p.Online ? "yes" : "no"],
+    ["Created", p.Created],
+    ["Achievements", (p.Achievements || []).join(", ") || "none yet"],
+  ];
+  for (const [key, value] of fields) {
+    info.append(el("dt", key), el("dd", value));
+  }
+
+  const history = await fetch("/player/" + encodeURIComponent(name) + "/history");
+  const events = history.ok ? await history.json() : [];
+  document.getElementById("player-events").replaceChildren(...events.map(eventItem));
+}
+
+function route() {
+  const match = location.hash.match(/^#\/player\/(.+)$/);
+  document.getElementById("home").hidden = !!match;
+  document.getElementById("player").hidden = !match;
+  if (match) {
+    showPlayer(decodeURIComponent(match[1]));
+  }
+}
+
+function connect() {
+  const status = document.getElementById("status");
+  const stream = new EventSource("/events");
+
+  stream.onopen = () => { status.textContent = "live"; };
+  stream.onerror = () => { status.textContent = "reconnecting..."; };
+  stream.addEventListener("snapshot", msg => showSnapshot(JSON.parse(msg.data)));
+  for (const kind of ["levelup", "item", "fight", "revelation", "achievement", "trade", "craft", "boss", "landmark"]) {
+    stream.addEventListener(kind, msg => addEvent(JSON.parse(msg.data)));
+  }
+}
+
+window.addEventListener("hashchange", route);
+loadLeaderboard();
+connect();
+route();
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/auth"
+	"github.com/kvitebjorn/idleinferno/internal/config"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+	"github.com/kvitebjorn/idleinferno/internal/irc"
+)
+
+// ircBridge lets the IRC gateway log players in and out of the world.
+type ircBridge struct {
+	s *Server
+
+	// Players currently in the world via IRC
+	players map[string]*model.Player
+	mut     sync.Mutex
+}
+
+var validName = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+func (b *ircBridge) Register(name, password, email, class string) error {
+	if !validName.MatchString(name) {
+		return errors.New("Invalid name, alphanumeric chars only allowed.")
+	}
+	class, err := model.ValidateClass(class)
+	if err != nil {
+		return err
+	}
+	if len(password) < 6 {
+		return errors.New("Password length requirement (6) not met.")
+	}
+	_, err = mail.ParseAddress(email)
+	if err != nil {
+		return errors.New("Invalid email.")
+	}
+	if b.s.db.ReadUser(name) != nil {
+		return errors.New("User name already exists.")
+	}
+	if b.s.db.ReadUserByEmail(email) != nil {
+		return errors.New("There is already a user for this email.")
+	}
+
+	hashedPassword, err := auth.Hash(password)
+	if err != nil {
+		return err
+	}
+	user := model.User{
+		Name:     name,
+		Email:    email,
+		Password: hashedPassword,
+		Class:    class,
+	}
+	if b.s.db.CreatePlayer(&user) == nil {
+		return fmt.Errorf("Failed to create user %s", name)
+	}
+	fmt.Println("Created user", name)
+	return nil
+}
+
+func (b *ircBridge) Login(name, password string) error {
+	player, _, err := b.s.login(name, password)
+	if err != nil {
+		return err
+	}
+	b.online(player)
+	return nil
+}
+
+func (b *ircBridge) Rejoin(name string) error {
+	user := b.s.db.ReadUser(name)
+	if user == nil {
+		return fmt.Errorf("User doesn't exist: %s", name)
+	}
+	player, _, err := b.s.enter(user)
+	if err != nil {
+		return err
+	}
+	b.online(player)
+	return nil
+}
+
+func (b *ircBridge) Logout(name string) {
+	b.mut.Lock()
+	player, ok := b.players[name]
+	delete(b.players, name)
+	b.mut.Unlock()
+
+	if ok {
+		b.s.logout(player)
+	}
+}
+
+func (b *ircBridge) online(player *model.Player) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	b.players[player.Name] = player
+	log.Println(player.Name, "joined from IRC.")
+}
+
+func (s *Server) initIRC(cfg *config.IRC) *irc.Gateway {
+	events := make([]model.EventKind, 0, len(cfg.Events))
+	for _, kind := range cfg.Events {
+		events = append(events, model.EventKind(kind))
+	}
+	return &irc.Gateway{
+		Server:   cfg.Server,
+		Nick:     cfg.Nick,
+		Channel:  cfg.Channel,
+		Handler:  &ircBridge{s: s, players: make(map[string]*model.Player)},
+		Events:   events,
+		MinLevel: cfg.MinLevel,
+		Clock:    s.clock,
+	}
+}
+
+// runIRC keeps the gateway connected, reconnecting whenever it drops.
+func (s *Server) runIRC() {
+	for {
+		fmt.Println("Connecting to IRC at", s.irc.Server)
+		err := s.irc.Run()
+		fmt.Println("IRC gateway stopped:", err.Error())
+		s.clock.Sleep(30 * time.Second)
+	}
+}
@@ -1,10 +1,36 @@
 package main
 
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+	"github.com/kvitebjorn/idleinferno/internal/game/sim"
+)
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		simulate(os.Args[2:])
+		return
+	}
+
 	server := initServer()
 	server.Run()
 }
 
 func initServer() *Server {
-	return &Server{}
+	return &Server{clock: clock.Real{}}
+}
+
+// simulate runs a seeded game without the server or database, and prints a balance report.
+func simulate(args []string) {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	players := flags.Int("players", 20, "number of virtual players")
+	ticks := flags.Int("ticks", 400*sim.TicksPerDay, "number of game ticks, one per minute of game time")
+	seed := flags.Uint64("seed", 1, "random seed")
+	flags.Parse(args)
+
+	report := sim.Run(sim.Config{Players: *players, Ticks: *ticks, Seed: *seed})
+	fmt.Print(report.String())
 }
@@ -10,6 +10,9 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -18,22 +21,42 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/kvitebjorn/idleinferno/internal/auth"
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+	"github.com/kvitebjorn/idleinferno/internal/config"
 	"github.com/kvitebjorn/idleinferno/internal/db"
 	"github.com/kvitebjorn/idleinferno/internal/db/sqlite"
 	"github.com/kvitebjorn/idleinferno/internal/game"
 	"github.com/kvitebjorn/idleinferno/internal/game/model"
+	"github.com/kvitebjorn/idleinferno/internal/game/render"
+	"github.com/kvitebjorn/idleinferno/internal/irc"
 	"github.com/kvitebjorn/idleinferno/internal/requests"
+	"github.com/kvitebjorn/idleinferno/internal/sse"
+	"github.com/kvitebjorn/idleinferno/internal/webhook"
 )
 
 type Server struct {
+	clock           clock.Clock
+	config          *config.Config
 	db              db.Database
 	game            *game.Game
-	broadcastBuffer *bytes.Buffer
+	irc             *irc.Gateway
+	spectators      *sse.Broker
+	broadcastBuffer *logBuffer
 }
 
 type Client struct {
 	Player *requests.Player
 	Conn   *websocket.Conn
+
+	// A websocket takes one writer at a time; broadcasts, replies and trade notices all go through send
+	writeMut sync.Mutex
+}
+
+// send writes a message to the client's connection.
+func (c *Client) send(msg requests.PlayerMessage) error {
+	c.writeMut.Lock()
+	defer c.writeMut.Unlock()
+	return c.Conn.WriteJSON(&msg)
 }
 
 var (
@@ -48,8 +71,14 @@ func (s *Server) Start() {
 	myRouter.HandleFunc("/user/{name}", s.getUser).Methods(http.MethodGet)
 	myRouter.HandleFunc("/user/e/{email}", s.getUserByEmail).Methods(http.MethodGet)
 	myRouter.HandleFunc("/user/create", s.createUser).Methods(http.MethodPost)
+	myRouter.HandleFunc("/map.svg", s.getMapSvg).Methods(http.MethodGet)
+	myRouter.HandleFunc("/map.png", s.getMapPng).Methods(http.MethodGet)
+	myRouter.HandleFunc("/players", s.getPlayers).Methods(http.MethodGet)
 	myRouter.HandleFunc("/player/{name}", s.getPlayer).Methods(http.MethodGet)
+	myRouter.HandleFunc("/player/{name}/history", s.getHistory).Methods(http.MethodGet)
+	myRouter.PathPrefix("/dashboard/").Handler(dashboardHandler()).Methods(http.MethodGet)
 	myRouter.HandleFunc("/ws", s.handleConnection)
+	myRouter.Handle("/events", s.spectators).Methods(http.MethodGet)
 
 	go handleMessages()
 	go s.sendLogsToWebSocket()
@@ -73,33 +102,159 @@ var (
 	BROADCAST = make(chan requests.PlayerMessage)
 )
 
-func home(w http.ResponseWriter, r *http.Request) {
-	fmt.Fprintf(w, "Welcome to idleinferno!")
-}
-
 func pong(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "Pong!")
 }
 
+func (s *Server) getMapSvg(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Write(render.SVG(s.game.World.Snapshot()))
+}
+
+func (s *Server) getMapPng(w http.ResponseWriter, r *http.Request) {
+	img, err := render.PNG(s.game.World.Snapshot())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(img)
+}
+
 func (s *Server) getPlayer(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	key := vars["name"]
-	maybePlayer := s.db.ReadPlayer(key)
+	maybePlayer := s.readPlayer(key)
 	if maybePlayer == nil {
 		return
 	}
-	encodedPlayer := requests.Player{
-		Name:      maybePlayer.Name,
-		Class:     maybePlayer.Class,
-		Xp:        maybePlayer.Stats.Xp,
-		Level:     maybePlayer.Stats.Level(),
-		ItemLevel: maybePlayer.ItemLevel(),
-		X:         maybePlayer.Location.X,
-		Y:         maybePlayer.Location.Y,
-		Created:   maybePlayer.Stats.Created,
-		Online:    maybePlayer.Stats.Online,
+	json.NewEncoder(w).Encode(encodePlayer(maybePlayer))
+}
+
+// readPlayer is the player as of the world's last snapshot if they're online, or as they were last saved if not.
+func (s *Server) readPlayer(name string) *model.Player {
+	if p := s.game.World.Snapshot().Player(name); p != nil {
+		return p
+	}
+	return s.db.ReadPlayer(name)
+}
+
+// getHistory pages through the events a player was involved in, newest first.
+// Filter with ?kind=fight,item (or repeat kind), and page with ?limit= and ?offset=.
+func (s *Server) getHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	key := vars["name"]
+	query := r.URL.Query()
+
+	kinds, err := parseEventKinds(query["kind"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := queryInt(query.Get("limit"), defaultHistoryLimit)
+	if err != nil || limit <= 0 {
+		http.Error(w, "Invalid limit.", http.StatusBadRequest)
+		return
+	}
+	offset, err := queryInt(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		http.Error(w, "Invalid offset.", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	events := s.db.ReadEvents(key, kinds, min(limit, maxHistoryLimit), offset)
+	json.NewEncoder(w).Encode(events)
+}
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+func queryInt(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// parseEventKinds accepts kinds either repeated or comma separated.
+func parseEventKinds(values []string) ([]model.EventKind, error) {
+	kinds := make([]model.EventKind, 0)
+	for _, value := range values {
+		for _, kind := range strings.Split(value, ",") {
+			kind = strings.ToLower(strings.TrimSpace(kind))
+			if kind == "" {
+				continue
+			}
+			if !slices.Contains(model.EventKinds, model.EventKind(kind)) {
+				return nil, fmt.Errorf("Unknown event kind: %s.", kind)
+			}
+			kinds = append(kinds, model.EventKind(kind))
+		}
+	}
+	return kinds, nil
+}
+
+// getPlayers lists every player, highest level first.
+func (s *Server) getPlayers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	players := s.db.ReadPlayers()
+	// Those online are further along than they were last saved
+	snapshot := s.game.World.Snapshot()
+	for i, p := range players {
+		if online := snapshot.Player(p.Name); online != nil {
+			players[i] = online
+		}
+	}
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].Stats.Level != players[j].Stats.Level {
+			return players[i].Stats.Level > players[j].Stats.Level
+		}
+		return players[i].Stats.TimeToLevel < players[j].Stats.TimeToLevel
+	})
+
+	encodedPlayers := make([]requests.Player, 0, len(players))
+	for _, p := range players {
+		encodedPlayers = append(encodedPlayers, encodePlayer(p))
+	}
+	json.NewEncoder(w).Encode(encodedPlayers)
+}
+
+func encodePlayer(p *model.Player) requests.Player {
+	achievements := make([]string, 0)
+	for _, a := range p.Unlocked() {
+		achievements = append(achievements, a.Name)
+	}
+
+	stash := make([]string, 0, len(p.Stash))
+	for _, i := range p.Stash {
+		stash = append(stash, i.ToString())
+	}
+
+	sets := make([]string, 0)
+	for _, s := range p.Sets() {
+		sets = append(sets, s.ToString())
+	}
+
+	return requests.Player{
+		Name:          p.Name,
+		Title:         p.Title(),
+		Achievements:  achievements,
+		Class:         p.Class,
+		Level:         p.Stats.Level,
+		TimeToLevel:   int64(p.Stats.TimeToLevel / time.Second),
+		NextLevel:     model.FormatDuration(p.Stats.TimeToLevel),
+		ItemLevel:     p.ItemLevel(),
+		BaseItemLevel: p.BaseItemLevel(),
+		Sets:          sets,
+		Stash:         stash,
+		X:             p.Location.X,
+		Y:             p.Location.Y,
+		Created:       p.Stats.Created.Format(time.DateTime),
+		Online:        p.Stats.Online,
 	}
-	json.NewEncoder(w).Encode(encodedPlayer)
 }
 
 func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
@@ -134,6 +289,12 @@ func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	var user requests.User
 	_ = json.NewDecoder(r.Body).Decode(&user)
+	class, err := model.ValidateClass(user.Class)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	user.Class = class
 	modelUser := model.User{
 		Name:     user.Name,
 		Email:    user.Email,
@@ -161,59 +322,42 @@ func (s *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
 	var msg requests.UserMessage
 	err = conn.ReadJSON(&msg)
 	if err != nil {
-		fmt.Println("%v %s", msg.Code, err.Error())
+		fmt.Println(msg.Code, err.Error())
 		return
 	}
 
 	user := msg.User
-	maybeUser := s.db.ReadUser(user.Name)
-	if maybeUser == nil {
-		fmt.Println("User doesn't exist:", user.Name)
-		return
-	}
-	if !auth.CheckHash(user.Password, maybeUser.Password) {
-		fmt.Println("Invalid user credentials for", user.Name)
-		return
-	}
-	if maybeUser.Online {
-		fmt.Println(user.Name, "is already online.")
-		return
-	}
-	err = s.db.UpdateUserOnline(user.Name)
+	gamePlayer, away, err := s.login(user.Name, user.Password)
 	if err != nil {
-		fmt.Println(user.Name, "failed to come online")
+		fmt.Println(err.Error())
 		return
 	}
 
 	userId := USER_COUNTER.Add(1)
 	if userId == math.MaxUint64-1 {
 		log.Println("Server full")
+		s.logout(gamePlayer)
 		return
 	}
 
 	player := requests.Player{Name: user.Name}
-	client := Client{&player, conn}
+	client := &Client{Player: &player, Conn: conn}
 	USERS_MU.Lock()
-	USERS[userId] = &client
+	USERS[userId] = client
 	USERS_MU.Unlock()
 
-	gamePlayer := s.db.ReadPlayer(player.Name)
-	updatedGamePlayer, err := s.game.World.Login(gamePlayer)
-	if err != nil {
-		fmt.Println(err.Error())
-		return
-	}
-	_ = s.db.UpdatePlayer(updatedGamePlayer)
-
 	connMsg := fmt.Sprintf("%s connected!", client.Player.Name)
 	log.Println(connMsg)
 
 	go func() {
 		// Wait for 2 seconds
-		time.Sleep(2 * time.Second)
+		s.clock.Sleep(2 * time.Second)
 
 		// We send this because they will usually miss their own login broadcast message due to lag and timing.
-		conn.WriteJSON(&requests.PlayerMessage{Player: SERVER_PLAYER, Message: connMsg, Code: requests.Chatter})
+		s.writeToConn(client, connMsg)
+		if away != nil {
+			s.writeToConn(client, away.ToString())
+		}
 	}()
 
 	// Listen for messages, respond if they are valid
@@ -225,31 +369,178 @@ func (s *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
 			delete(USERS, userId)
 			USERS_MU.Unlock()
 
-			s.game.World.Logout(gamePlayer)
-			_ = s.db.UpdateUserOffline(user.Name)
-			log.Println(user.Name, "went offline.")
+			s.logout(gamePlayer)
 			return
 		}
 
 		switch msg.Code {
 		case requests.Chatter:
-			reqMsg := strings.ToLower(strings.TrimSpace(msg.Message))
-			switch reqMsg {
+			// Names in the arguments keep their case
+			command, args, _ := strings.Cut(strings.TrimSpace(msg.Message), " ")
+			switch strings.ToLower(command) {
 			case "map":
-				s.writeToConn(conn, s.game.World.ToString())
+				s.writeToConn(client, s.game.World.ToString())
 			case "info":
-				p := s.db.ReadPlayer(msg.Player.Name)
-				s.writeToConn(conn, p.ToString())
+				p := s.readPlayer(user.Name)
+				s.writeToConn(client, p.ToString())
+			case "history":
+				s.writeToConn(client, s.history(user.Name, args))
+			case "trade":
+				s.writeToConn(client, s.trade(user.Name, args))
+			case "stash":
+				s.writeToConn(client, s.stash(user.Name, args))
+			case "craft":
+				s.writeToConn(client, s.craft(user.Name, args))
+			case "boss":
+				s.writeToConn(client, s.boss(user.Name))
 			default:
-				s.writeToConn(conn, "Invalid request, sinner.")
+				s.writeToConn(client, "Invalid request, sinner.")
 			}
 		default:
 		}
 	}
 }
 
-func (s *Server) writeToConn(c *websocket.Conn, msg string) {
-	c.WriteJSON(&requests.PlayerMessage{Player: SERVER_PLAYER, Message: msg, Code: requests.Chatter})
+// history is the in-game view of a player's recent events.
+func (s *Server) history(name, args string) string {
+	kinds, err := parseEventKinds(strings.Fields(args))
+	if err != nil {
+		return err.Error()
+	}
+
+	events := s.db.ReadEvents(name, kinds, defaultHistoryLimit, 0)
+	if len(events) == 0 {
+		return "Nothing has happened to you yet, sinner."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Your recent history:")
+	for _, e := range events {
+		fmt.Fprintf(&sb, "\n  [%s] %s", e.Time.Format(time.DateTime), e.Message)
+	}
+	return sb.String()
+}
+
+const stashUsage = `Your stash keeps the last items you replaced:
+  stash           compare your stash with what you're wearing
+  stash swap <n>  put the nth stashed item back on`
+
+// stash is the in-game view of a player's stash, and lets them swap items back.
+func (s *Server) stash(name, args string) string {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		lines, err := s.game.World.Snapshot().CompareStash(name)
+		if err != nil {
+			return err.Error()
+		}
+		if len(lines) == 0 {
+			return "Your stash is empty.\n" + stashUsage
+		}
+		return "Your stash:\n  " + strings.Join(lines, "\n  ")
+	}
+
+	if len(fields) != 2 || strings.ToLower(fields[0]) != "swap" {
+		return stashUsage
+	}
+	n, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return stashUsage
+	}
+	worn, stashed, err := s.game.World.Swap(name, n)
+	if err != nil {
+		return err.Error()
+	}
+	s.game.World.Publish()
+	if stashed == nil {
+		return fmt.Sprintf("You put the %s back on.", worn.ToString())
+	}
+	return fmt.Sprintf("You put the %s back on, and stashed the %s.", worn.ToString(), stashed.ToString())
+}
+
+// craft forges a better item from the player's worn and stashed items of one slot.
+func (s *Server) craft(name, args string) string {
+	fields := strings.Fields(args)
+	if len(fields) != 1 {
+		return craftUsage()
+	}
+	slot, err := model.ParseItemClass(fields[0])
+	if err != nil {
+		return err.Error()
+	}
+	c, err := s.game.World.Craft(name, slot, s.db.CraftItem)
+	if err != nil {
+		return err.Error()
+	}
+	s.game.World.Publish()
+	return fmt.Sprintf("The forges of %s roar, and you made a %s.", model.CircleNames[c.Circle], c.Forged.ToString())
+}
+
+func craftUsage() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "craft <slot> sacrifices your worn item in that slot, and stashed ones like it, to forge a better one.\n")
+	fmt.Fprintf(&sb, "Each circle has its own recipe, and the forge needs %s to cool down after:", model.FormatDuration(model.CraftCooldown))
+	for circle, recipe := range model.Recipes {
+		fmt.Fprintf(&sb, "\n  %s: %d items, %+d levels over the best of them", model.CircleNames[circle], recipe.Sacrifice, recipe.Bonus)
+	}
+	return sb.String()
+}
+
+// boss shows how the fight with the guardian of the player's circle is going.
+func (s *Server) boss(name string) string {
+	status, err := s.game.World.Snapshot().BossStatus(name)
+	if err != nil {
+		return err.Error()
+	}
+	return status
+}
+
+// login checks the user's credentials and places their player in the world.
+func (s *Server) login(name, password string) (*model.Player, *model.Away, error) {
+	maybeUser := s.db.ReadUser(name)
+	if maybeUser == nil {
+		return nil, nil, fmt.Errorf("User doesn't exist: %s", name)
+	}
+	if !auth.CheckHash(password, maybeUser.Password) {
+		return nil, nil, fmt.Errorf("Invalid user credentials for %s", name)
+	}
+	return s.enter(maybeUser)
+}
+
+// enter brings an already authenticated user online and into the world.
+func (s *Server) enter(user *model.User) (*model.Player, *model.Away, error) {
+	if user.Online {
+		return nil, nil, fmt.Errorf("%s is already online.", user.Name)
+	}
+	err := s.db.UpdateUserOnline(user.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s failed to come online", user.Name)
+	}
+
+	gamePlayer := s.db.ReadPlayer(user.Name)
+	updatedGamePlayer, away, err := s.game.World.Login(gamePlayer)
+	if err != nil {
+		_ = s.db.UpdateUserOffline(user.Name)
+		return nil, nil, err
+	}
+	s.game.World.SavePlayer(user.Name, func(player *model.Player) {
+		_ = s.db.UpdatePlayer(player)
+	})
+	// Reads go through the snapshot, so they shouldn't have to wait for the next tick to find the player online
+	s.game.World.Publish()
+
+	return updatedGamePlayer, away, nil
+}
+
+func (s *Server) logout(player *model.Player) {
+	s.game.World.Logout(player)
+	s.game.World.Publish()
+	_ = s.db.UpdatePlayer(player)
+	_ = s.db.UpdateUserOffline(player.Name)
+	log.Println(player.Name, "went offline.")
+}
+
+func (s *Server) writeToConn(c *Client, msg string) {
+	c.send(requests.PlayerMessage{Player: SERVER_PLAYER, Message: msg, Code: requests.Chatter})
 }
 
 func handleMessages() {
@@ -258,7 +549,7 @@ func handleMessages() {
 
 		USERS_MU.Lock()
 		for _, user := range USERS {
-			err := user.Conn.WriteJSON(msg)
+			err := user.send(msg)
 			if err != nil {
 				fmt.Println(err)
 			}
@@ -269,24 +560,56 @@ func handleMessages() {
 
 func (s *Server) Run() {
 	// Create a buffer to store logs
-	s.broadcastBuffer = new(bytes.Buffer)
+	s.broadcastBuffer = &logBuffer{}
 
 	// Tee the log output to both the console and the buffer
 	log.SetOutput(io.MultiWriter(os.Stdout, s.broadcastBuffer))
 
+	cfg, err := config.Load(config.FileName)
+	if err != nil {
+		log.Fatalln("Error loading config:", err.Error())
+	}
+	s.config = cfg
+
+	if cfg.ContentDir != "" {
+		err = model.LoadContent(cfg.ContentDir)
+		if err != nil {
+			log.Fatalln("Error loading content:", err.Error())
+		}
+	}
+
 	fmt.Println("Initializing database...")
-	s.db = &sqlite.Sqlite{}
+	s.db = &sqlite.Sqlite{Clock: s.clock}
 	s.db.Init()
 	fmt.Println("Database initialized successfully!")
 
 	fmt.Println("Starting idleinferno...")
 	fmt.Println("Initializing world...")
-	s.game = &game.Game{World: s.initWorld()}
+	s.game = &game.Game{World: s.initWorld(), Clock: s.clock}
 	fmt.Println("World initialized successfully!")
 
+	// Spectators can watch over SSE without logging in
+	s.spectators = sse.NewBroker(256)
+	s.spectators.Clock = s.clock
+	go s.streamEvents()
+
 	// Start the request listener
 	go s.Start()
 
+	// Start the IRC gateway, if we have one
+	if s.config.IRC != nil {
+		s.irc = s.initIRC(s.config.IRC)
+		go s.runIRC()
+		go s.irc.Relay(s.game.World.Subscribe(256))
+	}
+
+	// Start delivering events to webhooks
+	if len(s.config.Webhooks) > 0 {
+		dispatcher := webhook.NewDispatcher(s.config.Webhooks)
+		dispatcher.Clock = s.clock
+		go dispatcher.Run(s.game.World.Subscribe(256))
+	}
+
 	// Start the signal handler
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
@@ -294,9 +617,7 @@ func (s *Server) Run() {
 		for _ = range c {
 			// sig is a ^C, handle it
 			s.saveWorld(s.game.World)
-			for _, p := range s.game.World.Players {
-				s.db.UpdateUserOffline(p.Name)
-			}
+			s.offlineAll(s.game.World)
 			log.Fatalln("Server interrupted.")
 			os.Exit(1)
 		}
@@ -305,9 +626,7 @@ func (s *Server) Run() {
 	// Safety net log out all users on crash
 	defer func() {
 		s.saveWorld(s.game.World)
-		for _, p := range s.game.World.Players {
-			s.db.UpdateUserOffline(p.Name)
-		}
+		s.offlineAll(s.game.World)
 		log.Println("Server crashed.")
 	}()
 
@@ -320,7 +639,7 @@ func (s *Server) Run() {
 	s.saveWorld(s.game.World)
 	fmt.Println("World saved!")
 
-	err := s.db.Close()
+	err = s.db.Close()
 	if err != nil {
 		log.Fatalln("Error closing database:", err.Error())
 	}
@@ -328,22 +647,100 @@ func (s *Server) Run() {
 }
 
 func (s *Server) initWorld() *model.World {
-	world := &model.World{}
+	// Keep a history of what happens to everyone, saved with the world
+	world := &model.World{Clock: s.clock, History: true}
+	if len(s.config.CircleWidths) > model.WorldSize {
+		log.Fatalf("Error loading config: circle_widths has %d widths, for %d circles.\n", len(s.config.CircleWidths), model.WorldSize)
+	}
+	// Circles start as wide as the map draws them, unless configured otherwise
+	widths := model.LoadedMap().Widths()
+	for circle, width := range s.config.CircleWidths {
+		if width > 0 {
+			widths[circle] = width
+		}
+	}
+	world.Grid = model.NewGrid(widths)
+	if s.config.CatchUp != nil {
+		world.CatchUp = &model.CatchUp{
+			Share: s.config.CatchUp.Share,
+			Max:   time.Duration(s.config.CatchUp.MaxHours * float64(time.Hour)),
+			Tick:  game.TickInterval,
+		}
+	}
+	for _, boss := range s.db.ReadBosses() {
+		if boss.Circle >= 0 && boss.Circle < model.WorldSize {
+			world.Bosses[boss.Circle] = boss
+		}
+	}
 	return world
 }
 
 func (s *Server) saveWorld(world *model.World) {
-	for _, player := range world.Players {
+	world.Save(func(player *model.Player) {
 		_ = s.db.UpdatePlayer(player)
+	})
+	world.SaveBosses(func(boss *model.Boss) {
+		err := s.db.UpdateBoss(boss)
+		if err != nil {
+			fmt.Println("Failed to save the fight with", model.Guardians[boss.Circle].Name, err.Error())
+		}
+	})
+	err := world.SaveEvents(s.db.CreateEvents)
+	if err != nil {
+		fmt.Println("Failed to save the history, keeping it for the next try:", err.Error())
+	}
+}
+
+// offlineAll marks everyone in the world offline, as the server goes down.
+func (s *Server) offlineAll(world *model.World) {
+	world.Save(func(player *model.Player) {
+		_ = s.db.UpdateUserOffline(player.Name)
+	})
+}
+
+// streamEvents feeds game events and periodic world snapshots to spectators.
+func (s *Server) streamEvents() {
+	events := s.game.World.Subscribe(256)
+	ticker := s.clock.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	s.spectators.Send("snapshot", s.game.World.Snapshot())
+	for {
+		select {
+		case e := <-events:
+			s.spectators.Publish(string(e.Kind), e)
+		case <-ticker.C():
+			s.spectators.Send("snapshot", s.game.World.Snapshot())
+		}
 	}
+}
+
+// logBuffer collects the log for broadcasting. The logger writes to it from every goroutine,
+// so it's guarded, and drained in one go so no line slips in between reading and emptying it.
+type logBuffer struct {
+	buf bytes.Buffer
+	mut sync.Mutex
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.buf.Write(p)
+}
 
-	return
+// Drain empties the buffer, and returns what was in it.
+func (b *logBuffer) Drain() string {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	data := b.buf.String()
+	b.buf.Reset()
+	return data
 }
 
 func (s *Server) sendLogsToWebSocket() {
 	for {
 		// Periodically send logs from the buffer
-		logData := strings.TrimSpace(s.broadcastBuffer.String())
+		logData := strings.TrimSpace(s.broadcastBuffer.Drain())
 		if logData != "" {
 			msg := requests.PlayerMessage{
 				Player:  SERVER_PLAYER,
@@ -351,9 +748,8 @@ func (s *Server) sendLogsToWebSocket() {
 				Code:    requests.Chatter,
 			}
 			BROADCAST <- msg
-			s.broadcastBuffer.Reset()
 		}
 
-		time.Sleep(2 * time.Second)
+		s.clock.Sleep(2 * time.Second)
 	}
 }
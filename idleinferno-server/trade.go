@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+const tradeUsage = `Trading, with players in the same circle:
+  trade                                         list your open trades
+  trade offer <player> <slot|nothing> [slot]    offer your item in a slot, for theirs in another
+  trade counter <player> <slot|nothing> [slot]  answer an offer with your own terms
+  trade accept <player>                         accept their offer
+  trade cancel <player>                         withdraw or turn down a trade
+Slots are head, torso, legs, arms, gloves, boots, necklace, ring and weapon.`
+
+// trade handles the in-game trade command for the named player.
+func (s *Server) trade(name, args string) string {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return s.openTrades(name)
+	}
+	if len(fields) < 2 {
+		return tradeUsage
+	}
+
+	action, other := strings.ToLower(fields[0]), fields[1]
+	switch action {
+	case "offer", "counter":
+		if len(fields) < 3 || len(fields) > 4 {
+			return tradeUsage
+		}
+		give, err := parseSlot(fields[2])
+		if err != nil {
+			return err.Error()
+		}
+		var take *model.ItemClass
+		if len(fields) == 4 {
+			take, err = parseSlot(fields[3])
+			if err != nil {
+				return err.Error()
+			}
+		}
+
+		offer := s.game.World.Offer
+		if action == "counter" {
+			offer = s.game.World.Counter
+		}
+		t, err := offer(name, other, give, take)
+		if err != nil {
+			return err.Error()
+		}
+		s.game.World.Publish()
+		s.tell(other, fmt.Sprintf("%s Reply with trade accept, counter or cancel.", t.ToString()))
+		return fmt.Sprintf("Your offer is with %s. The items are in escrow until they answer.", other)
+
+	case "accept":
+		t, err := s.game.World.Accept(name, other, s.db.TradeItems)
+		if err != nil {
+			return err.Error()
+		}
+		s.game.World.Publish()
+		s.tell(other, fmt.Sprintf("%s accepted your trade.", name))
+		return fmt.Sprintf("Done: %s", t.Summary())
+
+	case "cancel":
+		_, err := s.game.World.Cancel(name, other)
+		if err != nil {
+			return err.Error()
+		}
+		s.game.World.Publish()
+		s.tell(other, fmt.Sprintf("%s called off your trade.", name))
+		return fmt.Sprintf("Your trade with %s is off.", other)
+
+	default:
+		return tradeUsage
+	}
+}
+
+func (s *Server) openTrades(name string) string {
+	trades := s.game.World.Snapshot().Trades(name)
+	if len(trades) == 0 {
+		return "You have no open trades.\n" + tradeUsage
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Your open trades:")
+	for _, t := range trades {
+		fmt.Fprintf(&sb, "\n  %s", t.ToString())
+	}
+	return sb.String()
+}
+
+// parseSlot reads a slot name, where "nothing" means no item.
+func parseSlot(name string) (*model.ItemClass, error) {
+	if strings.EqualFold(name, "nothing") {
+		return nil, nil
+	}
+	slot, err := model.ParseItemClass(name)
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// tell sends a message to a player, if they're connected.
+func (s *Server) tell(name, msg string) {
+	USERS_MU.Lock()
+	defer USERS_MU.Unlock()
+
+	for _, user := range USERS {
+		if user.Player.Name == name {
+			s.writeToConn(user, msg)
+		}
+	}
+}
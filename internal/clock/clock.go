@@ -0,0 +1,138 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is where the game gets the time from, so tests can control it.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+}
+
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real is the wall clock.
+type Real struct{}
+
+func (Real) Now() time.Time                         { return time.Now() }
+func (Real) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (Real) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+func (Real) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+func (t realTicker) Stop()               { t.t.Stop() }
+
+// Fake only moves when told to with Advance.
+type Fake struct {
+	now    time.Time
+	timers []*fakeTimer
+	mut    sync.Mutex
+}
+
+type fakeTimer struct {
+	when time.Time
+	// Zero for one shot timers
+	period time.Duration
+	ch     chan time.Time
+	clock  *Fake
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.now
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	t := &fakeTimer{when: f.now.Add(d), ch: make(chan time.Time, 1), clock: f}
+	f.timers = append(f.timers, t)
+	return t.ch
+}
+
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// NewTicker behaves like a real ticker: its channel holds one tick,
+// and the ticks that fall due while it's full are dropped, so Advance never waits on a reader.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	t := &fakeTimer{when: f.now.Add(d), period: d, ch: make(chan time.Time, 1), clock: f}
+	f.timers = append(f.timers, t)
+	return t
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() {
+	t.clock.mut.Lock()
+	defer t.clock.mut.Unlock()
+	t.clock.remove(t)
+}
+
+// remove must be called with f.mut held.
+func (f *Fake) remove(t *fakeTimer) {
+	for i, other := range f.timers {
+		if other == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Advance moves the clock forward by d, firing every timer and tick that falls due
+// on the way, in order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mut.Lock()
+	target := f.now.Add(d)
+
+	for {
+		sort.SliceStable(f.timers, func(i, j int) bool {
+			return f.timers[i].when.Before(f.timers[j].when)
+		})
+		if len(f.timers) == 0 || f.timers[0].when.After(target) {
+			break
+		}
+
+		t := f.timers[0]
+		f.now = t.when
+		if t.period == 0 {
+			f.remove(t)
+		} else {
+			t.when = t.when.Add(t.period)
+		}
+
+		select {
+		case t.ch <- f.now:
+		default:
+			// Nobody took the last tick yet
+		}
+	}
+
+	f.now = target
+	f.mut.Unlock()
+}
@@ -0,0 +1,107 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var epoch = time.Unix(0, 0).UTC()
+
+func TestFakeNowOnlyMovesOnAdvance(t *testing.T) {
+	f := NewFake(epoch)
+	if !f.Now().Equal(epoch) {
+		t.Fatalf("Now() = %v, want %v", f.Now(), epoch)
+	}
+	f.Advance(90 * time.Second)
+	if want := epoch.Add(90 * time.Second); !f.Now().Equal(want) {
+		t.Fatalf("Now() = %v, want %v", f.Now(), want)
+	}
+}
+
+func TestFakeAfterFiresOnceDue(t *testing.T) {
+	f := NewFake(epoch)
+	ch := f.After(time.Minute)
+
+	f.Advance(59 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("After fired early")
+	default:
+	}
+
+	f.Advance(time.Second)
+	select {
+	case got := <-ch:
+		if want := epoch.Add(time.Minute); !got.Equal(want) {
+			t.Fatalf("After sent %v, want %v", got, want)
+		}
+	default:
+		t.Fatal("After didn't fire")
+	}
+}
+
+func TestFakeTickerWithoutReaderDoesNotBlock(t *testing.T) {
+	f := NewFake(epoch)
+	ticker := f.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		f.Advance(time.Hour)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Advance blocked on a ticker nobody reads")
+	}
+
+	// Like a real ticker, only the first tick that fell due is held
+	if got, want := <-ticker.C(), epoch.Add(time.Second); !got.Equal(want) {
+		t.Fatalf("tick = %v, want %v", got, want)
+	}
+	select {
+	case got := <-ticker.C():
+		t.Fatalf("unexpected second tick %v", got)
+	default:
+	}
+}
+
+func TestFakeTickerKeepsTicking(t *testing.T) {
+	f := NewFake(epoch)
+	ticker := f.NewTicker(time.Minute)
+
+	for i := 1; i <= 3; i++ {
+		f.Advance(time.Minute)
+		if got, want := <-ticker.C(), epoch.Add(time.Duration(i)*time.Minute); !got.Equal(want) {
+			t.Fatalf("tick %d = %v, want %v", i, got, want)
+		}
+	}
+
+	ticker.Stop()
+	f.Advance(time.Minute)
+	select {
+	case got := <-ticker.C():
+		t.Fatalf("stopped ticker ticked at %v", got)
+	default:
+	}
+}
+
+func TestFakeSleepWakesOnAdvance(t *testing.T) {
+	f := NewFake(epoch)
+	woke := make(chan struct{})
+	go func() {
+		f.Sleep(time.Minute)
+		close(woke)
+	}()
+
+	// Keep advancing until the sleeper has registered its timer and been woken
+	for {
+		select {
+		case <-woke:
+			return
+		case <-time.After(time.Millisecond):
+			f.Advance(time.Minute)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func load(t *testing.T, json string) (*Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "idleinferno.json")
+	if err := os.WriteFile(path, []byte(json), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return Load(path)
+}
+
+func TestCatchUpShareMustBeBetweenZeroAndOne(t *testing.T) {
+	for _, share := range []string{"0", "0.5", "1"} {
+		cfg, err := load(t, `{"catch_up": {"share": `+share+`, "max_hours": 24}}`)
+		if err != nil {
+			t.Fatalf("share %s: %v", share, err)
+		}
+		if cfg.CatchUp == nil {
+			t.Fatalf("share %s: no catch_up loaded", share)
+		}
+	}
+
+	for _, share := range []string{"5", "1.01", "-0.5"} {
+		_, err := load(t, `{"catch_up": {"share": `+share+`}}`)
+		if err == nil || !strings.Contains(err.Error(), "share") {
+			t.Fatalf("share %s: err = %v, want the share rejected", share, err)
+		}
+	}
+}
+
+func TestCatchUpMaxHoursCantBeNegative(t *testing.T) {
+	if _, err := load(t, `{"catch_up": {"share": 0.5, "max_hours": -1}}`); err == nil {
+		t.Fatal("a negative max_hours was accepted")
+	}
+	if _, err := load(t, `{"catch_up": {"share": 0.5}}`); err != nil {
+		t.Fatalf("leaving max_hours out: %v", err)
+	}
+}
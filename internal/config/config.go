@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const FileName = "./idleinferno.json"
+
+type Config struct {
+	IRC      *IRC      `json:"irc"`
+	Webhooks []Webhook `json:"webhooks"`
+	CatchUp  *CatchUp  `json:"catch_up"`
+	// A directory of item names, blessings and curses to use instead of the built in ones
+	ContentDir string `json:"content_dir"`
+	// How many cells wide each circle starts out, from Limbo down. Circles widen as they fill up.
+	CircleWidths []int `json:"circle_widths"`
+}
+
+// CatchUp gives players a share of the progress they missed while offline.
+type CatchUp struct {
+	// Fraction of the time away that counts, from 0 to 1, e.g. 0.5
+	Share float64 `json:"share"`
+	// The most that can be credited for one absence, 24 when unset
+	MaxHours float64 `json:"max_hours"`
+}
+
+// IRC is optional, the gateway only runs when this is present in the config file.
+type IRC struct {
+	Server  string `json:"server"`
+	Nick    string `json:"nick"`
+	Channel string `json:"channel"`
+	// Event kinds to announce in the channel. Empty means level ups, achievements, guardians and crafts.
+	Events []string `json:"events"`
+	// Only announce events whose level is at least this
+	MinLevel int `json:"min_level"`
+}
+
+// Load reads the config file at path.
+// A missing file is not an error, we just run with the defaults.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.check()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// check catches the values that would load fine but make no sense, like crediting more time than was spent away.
+func (cfg *Config) check() error {
+	if c := cfg.CatchUp; c != nil {
+		if c.Share < 0 || c.Share > 1 {
+			return fmt.Errorf("catch_up: share is %v, it needs to be between 0 and 1.", c.Share)
+		}
+		if c.MaxHours < 0 {
+			return fmt.Errorf("catch_up: max_hours is %v, it can't be negative.", c.MaxHours)
+		}
+	}
+	return nil
+}
+
+// Webhook receives a signed JSON POST for each matching game event.
+type Webhook struct {
+	URL string `json:"url"`
+	// Event kinds to send, e.g. "levelup", "item", "fight", "revelation".
+	// Empty means all of them.
+	Events []string `json:"events"`
+	// Only send events whose level is at least this, for rare drops and big fights.
+	MinLevel int    `json:"min_level"`
+	Secret   string `json:"secret"`
+}
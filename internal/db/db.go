@@ -17,6 +17,22 @@ type Database interface {
 	ReadItems(playerName string) []*model.Item
 	UpdateItem(*model.Item) int64
 	DeleteItem(guid string)
+	// TradeItems moves the items in a trade to their new owners, all at once or not at all.
+	TradeItems(t *model.Trade) error
+	// CraftItem swaps the sacrificed items for the forged one, all at once or not at all.
+	CraftItem(c *model.Craft) error
+
+	// ReadBosses loads the fight with each guardian that has ever risen.
+	ReadBosses() []*model.Boss
+	// UpdateBoss saves the fight with a guardian, and the damage dealt to it, all at once.
+	UpdateBoss(b *model.Boss) error
+
+	CreateEvent(e *model.Event) *model.Event
+	// CreateEvents saves a batch of events, all at once or not at all.
+	CreateEvents(events []model.Event) error
+	// ReadEvents pages through the events involving a player, newest first.
+	// No kinds means every kind.
+	ReadEvents(playerName string, kinds []model.EventKind, limit, offset int) []*model.Event
 
 	ReadUser(name string) *model.User
 	ReadUserByEmail(email string) *model.User
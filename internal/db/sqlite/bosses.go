@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/kvitebjorn/idleinferno/internal/db/sqlite/queries"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+func (s *Sqlite) ReadBosses() []*model.Boss {
+	rows, err := s.db.Query(queries.ReadBossesSql)
+	checkErr(err)
+	defer rows.Close()
+
+	bosses := make(map[int]*model.Boss)
+	for rows.Next() {
+		var rose, fell sql.NullString
+		boss := &model.Boss{Damage: make(map[string]int)}
+
+		err = rows.Scan(&boss.Circle, &boss.HP, &rose, &fell)
+		checkErr(err)
+		if rose.Valid {
+			boss.Rose = parseTime(rose.String)
+		}
+		if fell.Valid {
+			boss.Fell = parseTime(fell.String)
+		}
+
+		bosses[boss.Circle] = boss
+	}
+	err = rows.Err()
+	checkErr(err)
+
+	damageRows, err := s.db.Query(queries.ReadBossDamageSql)
+	checkErr(err)
+	defer damageRows.Close()
+
+	for damageRows.Next() {
+		var circle, damage int
+		var player string
+
+		err = damageRows.Scan(&circle, &player, &damage)
+		checkErr(err)
+		if boss, ok := bosses[circle]; ok {
+			boss.Damage[player] = damage
+		}
+	}
+	err = damageRows.Err()
+	checkErr(err)
+
+	list := make([]*model.Boss, 0, len(bosses))
+	for _, boss := range bosses {
+		list = append(list, boss)
+	}
+	return list
+}
+
+func (s *Sqlite) UpdateBoss(b *model.Boss) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = updateBoss(tx, b)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func updateBoss(tx *sql.Tx, b *model.Boss) error {
+	_, err := tx.Exec(queries.UpsertBossSql, b.Circle, b.HP, nullTime(b.Rose), nullTime(b.Fell))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(queries.DeleteBossDamageSql, b.Circle)
+	if err != nil {
+		return err
+	}
+	for player, damage := range b.Damage {
+		_, err = tx.Exec(queries.CreateBossDamageSql, b.Circle, player, damage)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/kvitebjorn/idleinferno/internal/db/sqlite/queries"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+func (s *Sqlite) CraftItem(c *model.Craft) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = craftItem(tx, c)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func craftItem(tx *sql.Tx, c *model.Craft) error {
+	for _, item := range c.Sacrificed {
+		// Found this tick, and not saved yet
+		if item.Id == "" {
+			continue
+		}
+		res, err := tx.Exec(queries.SacrificeItemSql, item.Id, c.Player)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected != 1 {
+			return fmt.Errorf("The %s is no longer yours to sacrifice.", item.Name)
+		}
+	}
+
+	forged := c.Forged
+	forged.Id = uuid.New().String()
+	_, err := tx.Exec(queries.CreateItemSql, forged.Id, forged.Name, forged.Class, forged.ItemLevel, c.Player)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(queries.UpdatePlayerLastCraftSql, formatTime(c.Time), c.Player)
+	return err
+}
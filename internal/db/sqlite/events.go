@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/kvitebjorn/idleinferno/internal/db/sqlite/queries"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+func (s *Sqlite) CreateEvent(e *model.Event) *model.Event {
+	stmt, err := s.db.Prepare(queries.CreateEventSql)
+	checkErr(err)
+	defer stmt.Close()
+
+	res, err := stmt.Exec(
+		formatTime(e.Time),
+		e.Kind,
+		e.Player,
+		e.Opponent,
+		e.Winner,
+		e.PlayerRoll,
+		e.OpponentRoll,
+		e.Item,
+		e.Replaced,
+		e.Curse,
+		e.Level,
+		e.Message)
+	checkErr(err)
+	if err != nil {
+		return nil
+	}
+
+	e.Id, err = res.LastInsertId()
+	checkErr(err)
+
+	return e
+}
+
+func (s *Sqlite) CreateEvents(events []model.Event) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = createEvents(tx, events)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func createEvents(tx *sql.Tx, events []model.Event) error {
+	stmt, err := tx.Prepare(queries.CreateEventSql)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range events {
+		_, err = stmt.Exec(
+			formatTime(e.Time),
+			e.Kind,
+			e.Player,
+			e.Opponent,
+			e.Winner,
+			e.PlayerRoll,
+			e.OpponentRoll,
+			e.Item,
+			e.Replaced,
+			e.Curse,
+			e.Level,
+			e.Message)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sqlite) ReadEvents(playerName string, kinds []model.EventKind, limit, offset int) []*model.Event {
+	args := []any{playerName, playerName}
+	kindFilter := ""
+	if len(kinds) > 0 {
+		placeholders := make([]string, 0, len(kinds))
+		for _, kind := range kinds {
+			placeholders = append(placeholders, "?")
+			args = append(args, kind)
+		}
+		kindFilter = fmt.Sprintf("AND kind IN (%s)", strings.Join(placeholders, ", "))
+	}
+	args = append(args, limit, offset)
+
+	rows, err := s.db.Query(fmt.Sprintf(queries.ReadEventsByPlayerSql, kindFilter), args...)
+	checkErr(err)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	events := make([]*model.Event, 0)
+	for rows.Next() {
+		e := &model.Event{}
+		var created string
+		var opponent, winner, item, replaced sql.NullString
+
+		err = rows.Scan(
+			&e.Id,
+			&created,
+			&e.Kind,
+			&e.Player,
+			&opponent,
+			&winner,
+			&e.PlayerRoll,
+			&e.OpponentRoll,
+			&item,
+			&replaced,
+			&e.Curse,
+			&e.Level,
+			&e.Message)
+		checkErr(err)
+
+		e.Time = parseTime(created)
+		e.Opponent = opponent.String
+		e.Winner = winner.String
+		e.Item = item.String
+		e.Replaced = replaced.String
+		events = append(events, e)
+	}
+
+	err = rows.Err()
+	checkErr(err)
+
+	return events
+}
@@ -0,0 +1,193 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/db/sqlite/queries"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+type migration func(tx *sql.Tx) error
+
+// Migrations bring older databases up to date.
+// Each one runs once, in order, and the count that have run is kept in SQLite's user_version.
+// Only ever append to this list.
+var migrations = []migration{
+	migrateXpToTimeToLevel,
+	migrateAddLastSeen,
+	migrateAddAchievements,
+	migrateAddEvents,
+	migrateValidateClasses,
+	migrateAddTrades,
+	migrateAddStash,
+	migrateAddLastCraft,
+	migrateAddBosses,
+}
+
+func (s *Sqlite) migrate() error {
+	var version int
+	err := s.db.QueryRow(queries.ReadSchemaVersionSql).Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	for ; version < len(migrations); version++ {
+		fmt.Println("Migrating database to version", version+1)
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+
+		err = migrations[version](tx)
+		if err == nil {
+			_, err = tx.Exec(fmt.Sprintf(queries.UpdateSchemaVersionSql, version+1))
+		}
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateXpToTimeToLevel turns each player's xp, earned at 1xp per minute,
+// into their level and the time left until the next one.
+func migrateXpToTimeToLevel(tx *sql.Tx) error {
+	_, err := tx.Exec(queries.AddPlayerLevelColumnSql)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(queries.AddPlayerTtlColumnSql)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(queries.ReadPlayerXpsSql)
+	if err != nil {
+		return err
+	}
+	xps := make(map[string]uint64)
+	for rows.Next() {
+		var name string
+		var xp sql.NullInt64
+		err = rows.Scan(&name, &xp)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		xps[name] = uint64(max(xp.Int64, 0))
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for name, xp := range xps {
+		stats := model.FromXp(xp)
+		_, err = tx.Exec(queries.UpdatePlayerLevelSql, stats.Level, int64(stats.TimeToLevel/time.Second), name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func migrateAddLastSeen(tx *sql.Tx) error {
+	_, err := tx.Exec(queries.AddPlayerLastSeenColumnSql)
+	return err
+}
+
+func migrateAddAchievements(tx *sql.Tx) error {
+	_, err := tx.Exec(queries.CreateAchievementsTableSql)
+	return err
+}
+
+func migrateAddEvents(tx *sql.Tx) error {
+	for _, query := range []string{
+		queries.CreateEventsTableSql,
+		queries.CreateEventsPlayerIndexSql,
+		queries.CreateEventsOpponentIndexSql,
+	} {
+		_, err := tx.Exec(query)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateValidateClasses gives everyone who made up their own class at signup the default one,
+// and fixes the case of those who picked a real one.
+func migrateValidateClasses(tx *sql.Tx) error {
+	rows, err := tx.Query(queries.ReadPlayerClassesSql)
+	if err != nil {
+		return err
+	}
+	classes := make(map[string]string)
+	for rows.Next() {
+		var name, class string
+		err = rows.Scan(&name, &class)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		classes[name] = class
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for name, class := range classes {
+		valid, err := model.ValidateClass(class)
+		if err != nil {
+			valid = model.DefaultClass
+		}
+		if valid == class {
+			continue
+		}
+		_, err = tx.Exec(queries.UpdatePlayerClassSql, valid, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func migrateAddTrades(tx *sql.Tx) error {
+	_, err := tx.Exec(queries.CreateTradesTableSql)
+	return err
+}
+
+func migrateAddStash(tx *sql.Tx) error {
+	_, err := tx.Exec(queries.AddItemStashColumnSql)
+	return err
+}
+
+func migrateAddLastCraft(tx *sql.Tx) error {
+	_, err := tx.Exec(queries.AddPlayerLastCraftColumnSql)
+	return err
+}
+
+func migrateAddBosses(tx *sql.Tx) error {
+	for _, query := range []string{
+		queries.CreateBossesTableSql,
+		queries.CreateBossDamageTableSql,
+	} {
+		_, err := tx.Exec(query)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package queries
+
+const CreateAchievementsTableSql string = `CREATE TABLE achievements (
+	player    TEXT NOT NULL,
+	id        TEXT NOT NULL,
+	progress  INTEGER NOT NULL,
+	unlocked  TEXT,
+	PRIMARY KEY(player, id),
+	FOREIGN KEY(player) REFERENCES players(name)
+)`
+
+const (
+	ReadAchievementsByPlayerSql string = `SELECT id, progress, unlocked FROM achievements WHERE player = ?`
+	UpsertAchievementSql        string = `INSERT INTO achievements (player, id, progress, unlocked) VALUES (?, ?, ?, ?)
+	ON CONFLICT(player, id) DO UPDATE SET progress = excluded.progress, unlocked = excluded.unlocked`
+)
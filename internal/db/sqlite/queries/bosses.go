@@ -0,0 +1,25 @@
+package queries
+
+const CreateBossesTableSql string = `CREATE TABLE bosses (
+	circle  INTEGER PRIMARY KEY NOT NULL,
+	hp      INTEGER NOT NULL,
+	rose    TEXT,
+	fell    TEXT
+)`
+
+const CreateBossDamageTableSql string = `CREATE TABLE boss_damage (
+	circle  INTEGER NOT NULL,
+	player  TEXT NOT NULL,
+	damage  INTEGER NOT NULL,
+	PRIMARY KEY(circle, player),
+	FOREIGN KEY(circle) REFERENCES bosses(circle)
+)`
+
+const (
+	ReadBossesSql     string = `SELECT circle, hp, rose, fell FROM bosses`
+	ReadBossDamageSql string = `SELECT circle, player, damage FROM boss_damage`
+	UpsertBossSql     string = `INSERT INTO bosses (circle, hp, rose, fell) VALUES (?, ?, ?, ?)
+	ON CONFLICT(circle) DO UPDATE SET hp = excluded.hp, rose = excluded.rose, fell = excluded.fell`
+	DeleteBossDamageSql string = `DELETE FROM boss_damage WHERE circle = ?`
+	CreateBossDamageSql string = `INSERT INTO boss_damage (circle, player, damage) VALUES (?, ?, ?)`
+)
@@ -0,0 +1,7 @@
+package queries
+
+const (
+	// Only sacrifices the item if it's still the player's to give
+	SacrificeItemSql         string = `DELETE FROM items WHERE id = ? AND player = ?`
+	UpdatePlayerLastCraftSql string = `UPDATE players SET last_craft = ? WHERE name = ?`
+)
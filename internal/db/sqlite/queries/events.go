@@ -0,0 +1,32 @@
+package queries
+
+const CreateEventsTableSql string = `CREATE TABLE events (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	time          TEXT NOT NULL,
+	kind          TEXT NOT NULL,
+	player        TEXT NOT NULL,
+	opponent      TEXT,
+	winner        TEXT,
+	player_roll   INTEGER,
+	opponent_roll INTEGER,
+	item          TEXT,
+	replaced      TEXT,
+	curse         INTEGER,
+	level         INTEGER,
+	message       TEXT NOT NULL
+)`
+
+const (
+	CreateEventsPlayerIndexSql   string = `CREATE INDEX events_player ON events(player, id)`
+	CreateEventsOpponentIndexSql string = `CREATE INDEX events_opponent ON events(opponent, id)`
+)
+
+const (
+	CreateEventSql string = `INSERT INTO events
+	(time, kind, player, opponent, winner, player_roll, opponent_roll, item, replaced, curse, level, message)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	// The kind filter is filled in with one placeholder per kind
+	ReadEventsByPlayerSql string = `SELECT
+	id, time, kind, player, opponent, winner, player_roll, opponent_roll, item, replaced, curse, level, message
+	FROM events WHERE (player = ? OR opponent = ?) %s ORDER BY id DESC LIMIT ? OFFSET ?`
+)
@@ -10,8 +10,11 @@ const CreateItemsTableSql string = `CREATE TABLE items (
 )`
 
 const (
-	CreateItemSql        string = `INSERT INTO items (id, name, class, itemLevel, player) VALUES (?, ?, ?, ?, ?)`
-	ReadItemSql          string = `SELECT id, name, class, itemLevel, player FROM items WHERE id = ?`
-	ReadItemsByPlayerSql string = `SELECT id, name, class, itemLevel, player FROM items WHERE player = ?`
+	CreateItemSql string = `INSERT INTO items (id, name, class, itemLevel, player) VALUES (?, ?, ?, ?, ?)`
+	ReadItemSql   string = `SELECT id, name, class, itemLevel, player FROM items WHERE id = ?`
+	// Worn items have no stash position, and come first
+	ReadItemsByPlayerSql string = `SELECT id, name, class, itemLevel, player, stash FROM items WHERE player = ? ORDER BY stash`
+	UpdateItemStashSql   string = `UPDATE items SET stash = ? WHERE id = ?`
+	UpdateItemSql        string = `UPDATE items SET name = ?, class = ?, itemLevel = ?, player = ? WHERE id = ?`
 	DeleteItemSql        string = `DELETE FROM items WHERE id = ?`
 )
@@ -0,0 +1,35 @@
+package queries
+
+const (
+	ReadSchemaVersionSql   string = `PRAGMA user_version`
+	UpdateSchemaVersionSql string = `PRAGMA user_version = %d`
+)
+
+// Time to level replaces xp
+const (
+	AddPlayerLevelColumnSql string = `ALTER TABLE players ADD COLUMN level INTEGER NOT NULL DEFAULT 0`
+	AddPlayerTtlColumnSql   string = `ALTER TABLE players ADD COLUMN ttl INTEGER NOT NULL DEFAULT 0`
+	ReadPlayerXpsSql        string = `SELECT name, xp FROM players`
+	UpdatePlayerLevelSql    string = `UPDATE players SET level = ?, ttl = ? WHERE name = ?`
+)
+
+// Offline catch up
+const (
+	AddPlayerLastSeenColumnSql string = `ALTER TABLE players ADD COLUMN last_seen TEXT`
+)
+
+// Classes are picked from a list
+const (
+	ReadPlayerClassesSql string = `SELECT name, class FROM players`
+	UpdatePlayerClassSql string = `UPDATE players SET class = ? WHERE name = ?`
+)
+
+// Replaced items are kept in a stash
+const (
+	AddItemStashColumnSql string = `ALTER TABLE items ADD COLUMN stash INTEGER`
+)
+
+// Crafting cooldown
+const (
+	AddPlayerLastCraftColumnSql string = `ALTER TABLE players ADD COLUMN last_craft TEXT`
+)
@@ -16,11 +16,11 @@ const CreatePlayersTableSql string = `CREATE TABLE players (
 
 const (
 	CreatePlayerSql string = `INSERT INTO players
-	(id, name, email, password, class, xcoord, ycoord, xp, online, created, enabled)
-	VALUES (?, ?, ?, ?, ?, 0, 0, 1, 0, datetime(), 1)`
-	ReadPlayerSql   string = `SELECT id, name, class, xcoord, ycoord, xp, created, online FROM players WHERE name = ?`
-	ReadPlayersSql  string = `SELECT id, name, class, xcoord, ycoord, xp, created, online FROM players`
-	UpdatePlayerSql string = `UPDATE players SET xcoord = ?, ycoord = ?, xp = ? WHERE name = ?;`
+	(id, name, email, password, class, xcoord, ycoord, level, ttl, online, created, enabled)
+	VALUES (?, ?, ?, ?, ?, 0, 0, 0, ?, 0, ?, 1)`
+	ReadPlayerSql   string = `SELECT id, name, class, xcoord, ycoord, level, ttl, created, online, last_seen, last_craft FROM players WHERE name = ?`
+	ReadPlayersSql  string = `SELECT id, name, class, xcoord, ycoord, level, ttl, created, online, last_seen, last_craft FROM players`
+	UpdatePlayerSql string = `UPDATE players SET xcoord = ?, ycoord = ?, level = ?, ttl = ?, last_seen = ? WHERE name = ?;`
 
 	ReadUserSql        string = `SELECT name, password, online FROM players WHERE name = ?`
 	ReadUserByEmailSql string = `SELECT name, password, online FROM players WHERE email = ?`
@@ -0,0 +1,18 @@
+package queries
+
+const CreateTradesTableSql string = `CREATE TABLE trades (
+	id          TEXT PRIMARY KEY NOT NULL UNIQUE,
+	time        TEXT NOT NULL,
+	from_player TEXT NOT NULL,
+	to_player   TEXT NOT NULL,
+	given_item  TEXT,
+	taken_item  TEXT,
+	FOREIGN KEY(from_player) REFERENCES players(name),
+	FOREIGN KEY(to_player) REFERENCES players(name)
+)`
+
+const (
+	CreateTradeSql string = `INSERT INTO trades (id, time, from_player, to_player, given_item, taken_item) VALUES (?, ?, ?, ?, ?, ?)`
+	// Only moves the item if it's still with who we think has it
+	MoveItemSql string = `UPDATE items SET player = ? WHERE id = ? AND player = ?`
+)
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
 
+	"github.com/kvitebjorn/idleinferno/internal/clock"
 	"github.com/kvitebjorn/idleinferno/internal/db/sqlite/queries"
 	"github.com/kvitebjorn/idleinferno/internal/game/model"
 )
@@ -17,6 +19,34 @@ const DatabaseName = "./idleinferno.db"
 
 type Sqlite struct {
 	db *sql.DB
+
+	// Clock stamps new players. When nil, the wall clock is used.
+	Clock clock.Clock
+}
+
+func (s *Sqlite) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
+
+// Timestamps are stored as text, in the same format as SQLite's datetime()
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
+
+func nullTime(t time.Time) sql.NullString {
+	if t.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatTime(t), Valid: true}
+}
+
+func parseTime(s string) time.Time {
+	t, err := time.Parse(time.DateTime, s)
+	checkErr(err)
+	return t
 }
 
 func (s *Sqlite) Init() {
@@ -51,6 +81,11 @@ func (s *Sqlite) Init() {
 		fmt.Println("Database tables created successfully!")
 	}
 
+	err = s.migrate()
+	if err != nil {
+		log.Fatalln("Failed to migrate database:", err.Error())
+	}
+
 	return
 }
 
@@ -73,7 +108,9 @@ func (s *Sqlite) CreatePlayer(user *model.User) *model.Player {
 		user.Name,
 		user.Email,
 		user.Password,
-		user.Class)
+		user.Class,
+		int64(model.TimeForLevel(0)/time.Second),
+		formatTime(s.now()))
 	checkErr(err)
 
 	_, err = res.RowsAffected()
@@ -90,26 +127,38 @@ func (s *Sqlite) ReadPlayer(name string) *model.Player {
 		Stats:    &model.Stats{},
 	}
 
+	var created string
+	var ttl int64
+	var lastSeen, lastCraft sql.NullString
 	err := row.Scan(
 		&player.Id,
 		&player.Name,
 		&player.Class,
 		&player.Location.X,
 		&player.Location.Y,
-		&player.Stats.Xp,
-		&player.Stats.Created,
+		&player.Stats.Level,
+		&ttl,
+		&created,
 		&player.Stats.Online,
+		&lastSeen,
+		&lastCraft,
 	)
 
 	if err != nil {
 		return nil
 	}
-
-	items := s.ReadItems(player.Name)
-	for _, i := range items {
-		player.Inventory[i.Class] = i
+	player.Stats.TimeToLevel = time.Duration(ttl) * time.Second
+	player.Stats.Created = parseTime(created)
+	if lastSeen.Valid {
+		player.Stats.LastSeen = parseTime(lastSeen.String)
+	}
+	if lastCraft.Valid {
+		player.Stats.LastCraft = parseTime(lastCraft.String)
 	}
 
+	s.readInventory(player)
+	player.Achievements = s.readAchievements(player.Name)
+
 	return player
 }
 
@@ -126,23 +175,35 @@ func (s *Sqlite) ReadPlayers() []*model.Player {
 			Stats:    &model.Stats{},
 		}
 
+		var created string
+		var ttl int64
+		var lastSeen, lastCraft sql.NullString
 		err = rows.Scan(
 			&player.Id,
 			&player.Name,
 			&player.Class,
 			&player.Location.X,
 			&player.Location.Y,
-			&player.Stats.Xp,
-			&player.Stats.Created,
+			&player.Stats.Level,
+			&ttl,
+			&created,
 			&player.Stats.Online,
+			&lastSeen,
+			&lastCraft,
 		)
 		checkErr(err)
-
-		items := s.ReadItems(player.Name)
-		for _, i := range items {
-			player.Inventory[i.Class] = i
+		player.Stats.TimeToLevel = time.Duration(ttl) * time.Second
+		player.Stats.Created = parseTime(created)
+		if lastSeen.Valid {
+			player.Stats.LastSeen = parseTime(lastSeen.String)
+		}
+		if lastCraft.Valid {
+			player.Stats.LastCraft = parseTime(lastCraft.String)
 		}
 
+		s.readInventory(player)
+		player.Achievements = s.readAchievements(player.Name)
+
 		players = append(players, player)
 	}
 
@@ -186,54 +247,62 @@ func (s *Sqlite) UpdatePlayer(player *model.Player) int64 {
 	res, err := stmt.Exec(
 		player.Location.X,
 		player.Location.Y,
-		player.Stats.Xp,
+		player.Stats.Level,
+		int64(player.Stats.TimeToLevel/time.Second),
+		nullTime(player.Stats.LastSeen),
 		player.Name)
 	checkErr(err)
 
 	affected, err := res.RowsAffected()
 	checkErr(err)
 
-	// TODO: improve this lol
-	// First delete all the items we don't have anymore
-	knownItems := s.ReadItems(player.Name)
-	for _, i := range knownItems {
-		found := false
-		for _, j := range player.Inventory {
-			if j == nil {
-				continue
-			}
-
-			if j.Id == i.Id {
-				found = true
-				break
-			}
-		}
-		if found {
-			continue
+	s.updateItems(player)
+
+	s.updateAchievements(player)
+
+	return affected
+}
+
+func (s *Sqlite) readAchievements(playerName string) map[string]*model.Progress {
+	rows, err := s.db.Query(queries.ReadAchievementsByPlayerSql, playerName)
+	defer rows.Close()
+
+	checkErr(err)
+
+	achievements := make(map[string]*model.Progress)
+	for rows.Next() {
+		var id string
+		var unlocked sql.NullString
+		progress := &model.Progress{}
+
+		err = rows.Scan(&id, &progress.Count, &unlocked)
+		checkErr(err)
+		if unlocked.Valid {
+			progress.Unlocked = parseTime(unlocked.String)
 		}
-		s.DeleteItem(i.Id)
+
+		achievements[id] = progress
 	}
 
-	// Then add the new items that aren't in the db
-	for _, i := range player.Inventory {
-		if i == nil {
-			continue
-		}
+	err = rows.Err()
+	checkErr(err)
 
-		found := false
-		for _, j := range knownItems {
-			if j.Id == i.Id {
-				found = true
-				break
-			}
-		}
-		if found {
-			continue
-		}
-		s.CreateItem(i)
+	return achievements
+}
+
+func (s *Sqlite) updateAchievements(player *model.Player) {
+	if len(player.Achievements) == 0 {
+		return
 	}
 
-	return affected
+	stmt, err := s.db.Prepare(queries.UpsertAchievementSql)
+	checkErr(err)
+	defer stmt.Close()
+
+	for id, progress := range player.Achievements {
+		_, err = stmt.Exec(player.Name, id, progress.Count, nullTime(progress.Unlocked))
+		checkErr(err)
+	}
 }
 
 func (s *Sqlite) updateUserStatus(name string, online int) error {
@@ -308,35 +377,121 @@ func (s *Sqlite) ReadItem(guid string) *model.Item {
 }
 
 func (s *Sqlite) ReadItems(playerName string) []*model.Item {
+	items, _ := s.readItems(playerName)
+	return items
+}
+
+// readItems also returns where each item is in the player's stash, by item id.
+// Worn items aren't in the stash.
+func (s *Sqlite) readItems(playerName string) ([]*model.Item, map[string]sql.NullInt64) {
 	rows, err := s.db.Query(queries.ReadItemsByPlayerSql, playerName)
 	defer rows.Close()
 
 	checkErr(err)
 
 	items := make([]*model.Item, 0)
+	stash := make(map[string]sql.NullInt64)
 	for rows.Next() {
 		item := &model.Item{}
+		var position sql.NullInt64
 
 		err = rows.Scan(
 			&item.Id,
 			&item.Name,
 			&item.Class,
 			&item.ItemLevel,
-			&item.Player)
+			&item.Player,
+			&position)
 		checkErr(err)
 
 		items = append(items, item)
+		stash[item.Id] = position
 	}
 
 	err = rows.Err()
 	checkErr(err)
 
-	return items
+	return items, stash
 }
 
-func (s *Sqlite) UpdateItem(*model.Item) int64 {
-	// TODO
-	return 0
+func (s *Sqlite) readInventory(player *model.Player) {
+	items, stash := s.readItems(player.Name)
+	for _, i := range items {
+		if stash[i.Id].Valid {
+			player.Stash = append(player.Stash, i)
+		} else {
+			player.Inventory[i.Class] = i
+		}
+	}
+}
+
+// updateItems brings the player's items in the db in line with what they're wearing and have stashed.
+func (s *Sqlite) updateItems(player *model.Player) {
+	known, knownStash := s.readItems(player.Name)
+
+	held := make(map[*model.Item]sql.NullInt64)
+	for _, i := range player.Inventory {
+		if i != nil {
+			held[i] = sql.NullInt64{}
+		}
+	}
+	for n, i := range player.Stash {
+		held[i] = sql.NullInt64{Int64: int64(n), Valid: true}
+	}
+
+	// First delete all the items we don't have anymore
+	heldIds := make(map[string]bool)
+	for i := range held {
+		heldIds[i.Id] = true
+	}
+	for _, i := range known {
+		if !heldIds[i.Id] {
+			s.DeleteItem(i.Id)
+		}
+	}
+
+	// Then add the new items that aren't in the db, and move the rest in or out of the stash
+	for i, position := range held {
+		knownPosition, ok := knownStash[i.Id]
+		if !ok {
+			s.CreateItem(i)
+			knownPosition = sql.NullInt64{}
+		}
+		if knownPosition != position {
+			s.updateItemStash(i.Id, position)
+		}
+	}
+}
+
+func (s *Sqlite) updateItemStash(guid string, position sql.NullInt64) {
+	stmt, err := s.db.Prepare(queries.UpdateItemStashSql)
+	checkErr(err)
+	defer stmt.Close()
+
+	_, err = stmt.Exec(position, guid)
+	checkErr(err)
+}
+
+func (s *Sqlite) UpdateItem(item *model.Item) int64 {
+	stmt, err := s.db.Prepare(queries.UpdateItemSql)
+	checkErr(err)
+	defer stmt.Close()
+
+	res, err := stmt.Exec(
+		item.Name,
+		item.Class,
+		item.ItemLevel,
+		item.Player,
+		item.Id)
+	checkErr(err)
+	if err != nil {
+		return 0
+	}
+
+	affected, err := res.RowsAffected()
+	checkErr(err)
+
+	return affected
 }
 
 func (s *Sqlite) DeleteItem(guid string) {
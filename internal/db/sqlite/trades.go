@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kvitebjorn/idleinferno/internal/db/sqlite/queries"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+func (s *Sqlite) TradeItems(t *model.Trade) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = tradeItems(tx, t, s.now())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func tradeItems(tx *sql.Tx, t *model.Trade, now time.Time) error {
+	var given, taken sql.NullString
+	if t.Give != nil {
+		err := moveItem(tx, t.Give, t.From, t.To)
+		if err != nil {
+			return err
+		}
+		given = sql.NullString{String: t.Give.Id, Valid: true}
+	}
+	if t.Take != nil {
+		err := moveItem(tx, t.Take, t.To, t.From)
+		if err != nil {
+			return err
+		}
+		taken = sql.NullString{String: t.Take.Id, Valid: true}
+	}
+
+	// The trade id is unique, so the same trade can never be carried out twice
+	_, err := tx.Exec(queries.CreateTradeSql, t.Id, formatTime(now), t.From, t.To, given, taken)
+	return err
+}
+
+// moveItem hands an item over, failing if it has already moved on.
+func moveItem(tx *sql.Tx, item *model.Item, from, to string) error {
+	// Found this tick, and not saved yet
+	if item.Id == "" {
+		item.Id = uuid.New().String()
+		_, err := tx.Exec(queries.CreateItemSql, item.Id, item.Name, item.Class, item.ItemLevel, to)
+		return err
+	}
+
+	res, err := tx.Exec(queries.MoveItemSql, to, item.Id, from)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return fmt.Errorf("The %s is no longer %s's to trade.", item.Name, from)
+	}
+	return nil
+}
@@ -1,22 +1,41 @@
 package game
 
 import (
+	"math/rand/v2"
 	"time"
 
+	"github.com/kvitebjorn/idleinferno/internal/clock"
 	"github.com/kvitebjorn/idleinferno/internal/game/model"
 )
 
+// Each tick of the game loop is a minute of game time
+const TickInterval = 60 * time.Second
+
 type Game struct {
 	World *model.World
+	// Clock drives the game loop. When nil, the wall clock is used.
+	Clock clock.Clock
+}
+
+// New creates a game with every roll driven by rng, so that a seeded rng replays
+// the same game. A nil rng gets a randomly seeded one.
+func New(world *model.World, rng *rand.Rand) *Game {
+	world.Rand = rng
+	return &Game{World: world}
 }
 
 func (g *Game) Run(saveFn func(world *model.World)) {
-	ticker := time.NewTicker(60 * time.Second)
+	c := g.Clock
+	if c == nil {
+		c = clock.Real{}
+	}
+
+	ticker := c.NewTicker(TickInterval)
 	quit := make(chan struct{})
 	for {
 		select {
 
-		case <-ticker.C:
+		case <-ticker.C():
 			g.tick()
 			saveFn(g.World)
 
@@ -27,10 +46,18 @@ func (g *Game) Run(saveFn func(world *model.World)) {
 	}
 }
 
+// Step advances the game by a single tick, without waiting on the clock.
+func (g *Game) Step() {
+	g.tick()
+}
+
 func (g *Game) tick() {
-	g.World.Wander()
+	g.World.Wander(TickInterval)
+	g.World.Haunt()
 	g.World.Scavenge()
 	g.World.Arena()
+	g.World.Siege()
 	g.World.Revelation()
+	g.World.Publish()
 	return
 }
@@ -0,0 +1,217 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Achievement is earned by seeing Count events of a kind, or by reaching a state.
+// To add one, add it to Achievements. The game loop doesn't need to know about it.
+type Achievement struct {
+	Id          string
+	Name        string
+	Description string
+	// Title is what the player is known as once they've earned it, if anything
+	Title string
+
+	// Event counts towards the achievement when When, if set, is true for the player.
+	Event EventKind
+	When  func(p *Player, e Event) bool
+	Count int
+
+	// Reached, if set, is checked every tick instead of counting events.
+	Reached func(p *Player) bool
+}
+
+// Progress is how far along a player is with an achievement.
+type Progress struct {
+	Count    int
+	Unlocked time.Time
+}
+
+func (p *Progress) IsUnlocked() bool {
+	return !p.Unlocked.IsZero()
+}
+
+func won(p *Player, e Event) bool {
+	return e.Winner == p.Name
+}
+
+func isPlayer(p *Player, e Event) bool {
+	return e.Player == p.Name
+}
+
+// Later achievements outrank earlier ones when picking a player's title.
+var Achievements = []Achievement{
+	{
+		Id:          "first_item",
+		Name:        "Scavenger",
+		Description: "Equip your first item.",
+		Event:       ItemEvent,
+		When:        isPlayer,
+		Count:       1,
+	},
+	{
+		Id:          "first_blood",
+		Name:        "First Blood",
+		Description: "Win a fight in the arena.",
+		Event:       FightEvent,
+		When:        won,
+		Count:       1,
+	},
+	{
+		Id:          "blessed",
+		Name:        "Beloved of Beatrice",
+		Description: "Receive 10 blessings.",
+		Title:       "Beloved of Beatrice",
+		Event:       RevelationEvent,
+		When: func(p *Player, e Event) bool {
+			return isPlayer(p, e) && !e.Curse
+		},
+		Count: 10,
+	},
+	{
+		Id:          "cursed",
+		Name:        "Survivor",
+		Description: "Survive 10 curses.",
+		Title:       "the Accursed",
+		Event:       RevelationEvent,
+		When: func(p *Player, e Event) bool {
+			return isPlayer(p, e) && e.Curse
+		},
+		Count: 10,
+	},
+	{
+		Id:          "unique_item",
+		Name:        "Relic Hunter",
+		Description: "Equip an item of level 50 or higher.",
+		Title:       "Keeper of Relics",
+		Event:       ItemEvent,
+		When: func(p *Player, e Event) bool {
+			return isPlayer(p, e) && e.Level >= 50
+		},
+		Count: 1,
+	},
+	{
+		Id:          "arena_wins",
+		Name:        "Gladiator",
+		Description: "Win 100 fights in the arena.",
+		Title:       "Gladiator of Dis",
+		Event:       FightEvent,
+		When:        won,
+		Count:       100,
+	},
+	{
+		Id:          "circle_9",
+		Name:        "Rock Bottom",
+		Description: "Reach the ninth circle.",
+		Title:       "Traitor of Caina",
+		Reached: func(p *Player) bool {
+			return p.Location.Y == WorldSize-1
+		},
+	},
+	{
+		Id:          "level_50",
+		Name:        "Halfway Down",
+		Description: "Reach level 50.",
+		Reached: func(p *Player) bool {
+			return p.Stats.Level >= 50
+		},
+	},
+	{
+		Id:          "level_100",
+		Name:        "Centurion",
+		Description: "Reach level 100.",
+		Title:       "Centurion of the Inferno",
+		Reached: func(p *Player) bool {
+			return p.Stats.Level >= 100
+		},
+	},
+}
+
+func (p *Player) progress(id string) *Progress {
+	if p.Achievements == nil {
+		p.Achievements = make(map[string]*Progress)
+	}
+	progress, ok := p.Achievements[id]
+	if !ok {
+		progress = &Progress{}
+		p.Achievements[id] = progress
+	}
+	return progress
+}
+
+// Title is what the player is known as, from the best achievement they've earned.
+func (p *Player) Title() string {
+	title := ""
+	for _, a := range Achievements {
+		if a.Title == "" {
+			continue
+		}
+		if progress, ok := p.Achievements[a.Id]; ok && progress.IsUnlocked() {
+			title = a.Title
+		}
+	}
+	return title
+}
+
+// Unlocked lists the achievements the player has earned.
+func (p *Player) Unlocked() []Achievement {
+	unlocked := make([]Achievement, 0)
+	for _, a := range Achievements {
+		if progress, ok := p.Achievements[a.Id]; ok && progress.IsUnlocked() {
+			unlocked = append(unlocked, a)
+		}
+	}
+	return unlocked
+}
+
+// countAchievements counts e towards the achievements of everyone involved in it.
+func (w *World) countAchievements(e Event) {
+	for _, name := range []string{e.Player, e.Opponent} {
+		player := w.player(name)
+		if player == nil {
+			continue
+		}
+		for _, a := range Achievements {
+			if a.Event != e.Kind || (a.When != nil && !a.When(player, e)) {
+				continue
+			}
+			progress := player.progress(a.Id)
+			if progress.IsUnlocked() {
+				continue
+			}
+			progress.Count++
+			if progress.Count >= a.Count {
+				w.unlock(player, a, progress)
+			}
+		}
+	}
+}
+
+// checkAchievements unlocks any state based achievements the player has reached.
+func (w *World) checkAchievements(player *Player) {
+	for _, a := range Achievements {
+		if a.Reached == nil || !a.Reached(player) {
+			continue
+		}
+		progress := player.progress(a.Id)
+		if !progress.IsUnlocked() {
+			w.unlock(player, a, progress)
+		}
+	}
+}
+
+func (w *World) unlock(player *Player, a Achievement, progress *Progress) {
+	progress.Unlocked = w.clock().Now()
+
+	message := fmt.Sprintf("%s earned the achievement %s: %s", player.Name, a.Name, a.Description)
+	if a.Title != "" {
+		message += fmt.Sprintf(" They shall be known as %s, %s!", player.Name, a.Title)
+	}
+	w.emit(Event{
+		Kind:    AchievementEvent,
+		Player:  player.Name,
+		Message: message,
+	})
+}
@@ -0,0 +1,50 @@
+package model_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/kvitebjorn/idleinferno/internal/game"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+var crowdSizes = []int{1000, 5000, 10000}
+
+// crowd fills a world with players, all equipped so that everyone with a neighbor fights.
+func crowd(b *testing.B, players int) *model.World {
+	b.Helper()
+	w, _, _ := newWorld(1)
+	rng := rand.New(rand.NewPCG(1, 1))
+	for i := range players {
+		p := newSinner(fmt.Sprintf("sinner%05d", i), rng.IntN(model.DefaultCircleWidth), rng.IntN(model.WorldSize), 1+rng.IntN(50))
+		if _, _, err := w.Login(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return w
+}
+
+func BenchmarkWander(b *testing.B) {
+	for _, n := range crowdSizes {
+		b.Run(fmt.Sprintf("players=%d", n), func(b *testing.B) {
+			w := crowd(b, n)
+			b.ResetTimer()
+			for range b.N {
+				w.Wander(game.TickInterval)
+			}
+		})
+	}
+}
+
+func BenchmarkArena(b *testing.B) {
+	for _, n := range crowdSizes {
+		b.Run(fmt.Sprintf("players=%d", n), func(b *testing.B) {
+			w := crowd(b, n)
+			b.ResetTimer()
+			for range b.N {
+				w.Arena()
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// BossCooldown is how long a guardian takes to rise again after it falls
+	BossCooldown = 24 * time.Hour
+	// BossBonus is the percentage of the time to level taken off for bringing a guardian down.
+	// Each player gets it in proportion to the damage they dealt.
+	BossBonus = 50.0
+	// BossHitPenalty is the percentage added to the time to level of whoever a guardian strikes
+	BossHitPenalty = 0.5
+)
+
+// Guardian is the boss of a circle, who everyone in the circle fights together.
+type Guardian struct {
+	Name  string
+	Level int
+	HP    int
+	// Loot is how many items of about its level it leaves to share out
+	Loot int
+	// Taunts are what it says as it's worn down to 75%, 50% and 25% of its HP
+	Taunts [3]string
+}
+
+// Guardians by circle.
+var Guardians = [WorldSize]Guardian{
+	{
+		Name: "Charon", Level: 10, HP: 2000, Loot: 3,
+		Taunts: [3]string{
+			"Woe unto you, depraved souls! Hope not ever to see Heaven!",
+			"I come to lead you to the other shore, into eternal dark, into fire and into ice!",
+			"By other ways, by other ports, you'll reach the shore. Not here!",
+		},
+	},
+	{
+		Name: "Minos", Level: 18, HP: 4000, Loot: 3,
+		Taunts: [3]string{
+			"You who come to this place of pain, beware how you enter and in whom you trust!",
+			"Let not the broadness of the entrance deceive you!",
+			"My tail wraps around me once for every circle I send you down!",
+		},
+	},
+	{
+		Name: "Cerberus", Level: 26, HP: 7000, Loot: 4,
+		Taunts: [3]string{
+			"The three-throated beast barks over the drowned in the rain.",
+			"Cerberus tears at the spirits, flays them, and rends them limb from limb.",
+			"Cerberus gulps down fistfuls of earth, and writhes on.",
+		},
+	},
+	{
+		Name: "Plutus", Level: 34, HP: 10000, Loot: 4,
+		Taunts: [3]string{
+			"Pape Satàn, pape Satàn aleppe!",
+			"Plutus swells with rage, as sails bellied by the wind.",
+			"Plutus falls, as sails collapse when the mast snaps.",
+		},
+	},
+	{
+		Name: "Phlegyas", Level: 42, HP: 14000, Loot: 5,
+		Taunts: [3]string{
+			"Now you are caught, wicked soul!",
+			"Phlegyas bellows across the Styx, and the skiff cuts deeper through the mud.",
+			"The boatman's rage boils over, like a man who learns he has been badly deceived.",
+		},
+	},
+	{
+		Name: "the Furies", Level: 50, HP: 19000, Loot: 5,
+		Taunts: [3]string{
+			"Let Medusa come, and we will turn them into stone!",
+			"The Furies tear their breasts with their nails, and shriek so loud the walls of Dis shake.",
+			"We avenged ourselves poorly on Theseus' assault!",
+		},
+	},
+	{
+		Name: "the Minotaur", Level: 58, HP: 25000, Loot: 6,
+		Taunts: [3]string{
+			"The infamy of Crete gnaws at itself, consumed with rage.",
+			"The Minotaur plunges this way and that, like a bull that has taken its death blow.",
+			"The Minotaur staggers across the broken rocks, and the stones shift under its weight.",
+		},
+	},
+	{
+		Name: "Geryon", Level: 66, HP: 32000, Loot: 6,
+		Taunts: [3]string{
+			"Behold the beast with the pointed tail, who passes mountains and breaks walls and weapons!",
+			"Geryon's face is that of a just man, so kindly is its skin, and the rest of it a serpent.",
+			"Geryon lashes its forked tail in the void, like a scorpion's.",
+		},
+	},
+	{
+		Name: "Lucifer", Level: 80, HP: 50000, Loot: 7,
+		Taunts: [3]string{
+			"Vexilla regis prodeunt inferni!",
+			"Lucifer beats his six wings, and the winds freeze all of Cocytus.",
+			"Lucifer weeps from six eyes, and down three chins runs tears and bloody drool.",
+		},
+	},
+}
+
+// Boss is how the fight with a circle's guardian stands.
+type Boss struct {
+	Circle int
+	HP     int
+	// Damage is how much each player has dealt the guardian since it rose
+	Damage map[string]int
+	// Rose is when the guardian last rose, and Fell when it was last brought down
+	Rose time.Time
+	Fell time.Time
+}
+
+func (b *Boss) Guardian() *Guardian {
+	return &Guardians[b.Circle]
+}
+
+func (b *Boss) Alive() bool {
+	return b.HP > 0
+}
+
+func (b *Boss) copy() *Boss {
+	c := *b
+	c.Damage = maps.Clone(b.Damage)
+	return &c
+}
+
+// Siege raises the guardians that are done cooling down,
+// and has everyone equipped in a risen guardian's circle fight it.
+func (w *World) Siege() {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	now := w.clock().Now()
+	for circle := range WorldSize {
+		boss := w.Bosses[circle]
+		if boss == nil {
+			boss = &Boss{Circle: circle}
+			w.Bosses[circle] = boss
+		}
+		if !boss.Alive() {
+			if now.Before(boss.Fell.Add(BossCooldown)) {
+				continue
+			}
+			w.raise(boss, now)
+		}
+		w.besiege(boss)
+	}
+}
+
+// raise must be called with w.mut held.
+func (w *World) raise(boss *Boss, now time.Time) {
+	guardian := boss.Guardian()
+	boss.HP = guardian.HP
+	boss.Damage = make(map[string]int)
+	boss.Rose = now
+	w.emit(Event{
+		Kind:     BossEvent,
+		Opponent: guardian.Name,
+		Level:    guardian.Level,
+		Message: fmt.Sprintf("The guardian of %s, %s, rises! Everyone in the circle will face it together.",
+			CircleNames[boss.Circle], guardian.Name),
+	})
+}
+
+// besiege is a round of the fight with a guardian: everyone in its circle strikes it,
+// and it strikes one of them back.
+// Must be called with w.mut held.
+func (w *World) besiege(boss *Boss) {
+	fighters := make([]*Player, 0)
+	for _, player := range w.Players {
+		if player.Location.Y == boss.Circle && player.ItemLevel() > 0 {
+			fighters = append(fighters, player)
+		}
+	}
+	if len(fighters) == 0 {
+		return
+	}
+
+	if boss.Damage == nil {
+		boss.Damage = make(map[string]int)
+	}
+	guardian := boss.Guardian()
+	before := boss.HP
+	for _, player := range fighters {
+		damage := w.roll(player)
+		boss.HP -= damage
+		boss.Damage[player.Name] += damage
+	}
+	fighters[w.rng().IntN(len(fighters))].Stats.Penalty(BossHitPenalty)
+
+	if !boss.Alive() {
+		boss.HP = 0
+		w.fell(boss)
+		return
+	}
+	for i, taunt := range guardian.Taunts {
+		threshold := guardian.HP * (3 - i) / 4
+		if before > threshold && boss.HP <= threshold {
+			w.emit(Event{
+				Kind:     BossEvent,
+				Opponent: guardian.Name,
+				Level:    guardian.Level,
+				Message:  fmt.Sprintf("%s, at %d%%: %s", capitalise(guardian.Name), 75-25*i, taunt),
+			})
+		}
+	}
+}
+
+// fell shares out the time bonus and the loot of a beaten guardian by the damage each player dealt.
+// Players who have since left the world miss out on their share.
+// Must be called with w.mut held.
+func (w *World) fell(boss *Boss) {
+	guardian := boss.Guardian()
+	boss.Fell = w.clock().Now()
+
+	contributors := boss.contributors()
+	total := 0
+	for _, name := range contributors {
+		total += boss.Damage[name]
+	}
+	if total == 0 {
+		return
+	}
+
+	// Each item goes to a contributor picked with odds by their damage
+	loot := make(map[string][]*Item)
+	for range guardian.Loot {
+		level := guardian.Level + w.rng().IntN(5)
+		item := createItem(w.rng(), ItemClass(w.rng().IntN(len(itemClassNames))), level)
+		roll := w.rng().IntN(total)
+		for _, name := range contributors {
+			roll -= boss.Damage[name]
+			if roll < 0 {
+				loot[name] = append(loot[name], item)
+				break
+			}
+		}
+	}
+
+	w.emit(Event{
+		Kind:     BossEvent,
+		Player:   contributors[0],
+		Opponent: guardian.Name,
+		Winner:   contributors[0],
+		Level:    guardian.Level,
+		Message: fmt.Sprintf("The guardian of %s, %s, has fallen! %s dealt the most damage, and it rises again in %s.",
+			CircleNames[boss.Circle], guardian.Name, contributors[0], FormatDuration(BossCooldown)),
+	})
+
+	for _, name := range contributors {
+		player := w.player(name)
+		if player == nil {
+			continue
+		}
+		share := float64(boss.Damage[name]) / float64(total)
+		player.Stats.Bonus(BossBonus * share)
+
+		e := Event{
+			Kind:     BossEvent,
+			Player:   name,
+			Opponent: guardian.Name,
+			Level:    guardian.Level,
+			Message:  fmt.Sprintf("%s dealt %d of the %d damage done to %s", name, boss.Damage[name], total, guardian.Name),
+		}
+		for _, item := range loot[name] {
+			replaced, received := player.receive(item)
+			e.Item = item.Name
+			if replaced != nil {
+				e.Replaced = replaced.Name
+			}
+			e.Message += fmt.Sprintf(", and took a %s%s", item.ToString(), received)
+		}
+		w.emit(e)
+	}
+	boss.Damage = make(map[string]int)
+}
+
+// contributors are the players who damaged the guardian, the most damage first.
+func (b *Boss) contributors() []string {
+	names := make([]string, 0, len(b.Damage))
+	for name, damage := range b.Damage {
+		if damage > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if b.Damage[names[i]] != b.Damage[names[j]] {
+			return b.Damage[names[i]] > b.Damage[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// BossStatus describes the fight with the guardian of the player's circle, as it stood when the snapshot was taken.
+func (s *Snapshot) BossStatus(name string) (string, error) {
+	player := s.Player(name)
+	if player == nil {
+		return "", errors.New("You need to be in the world to face a guardian.")
+	}
+	circle := player.Location.Y
+	guardian := &Guardians[circle]
+	boss := s.bosses[circle]
+	if boss == nil || !boss.Alive() {
+		status := fmt.Sprintf("The guardian of %s, %s, is nowhere to be seen.", CircleNames[circle], guardian.Name)
+		if boss != nil {
+			if ready := boss.Fell.Add(BossCooldown); s.Time.Before(ready) {
+				status += fmt.Sprintf(" It rises again in %s.", FormatDuration(ready.Sub(s.Time)))
+			}
+		}
+		return status, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s, guardian of %s, level %d: %d/%d HP",
+		capitalise(guardian.Name), CircleNames[circle], guardian.Level, boss.HP, guardian.HP)
+	for _, contributor := range boss.contributors() {
+		fmt.Fprintf(&sb, "\n  %s: %d damage", contributor, boss.Damage[contributor])
+	}
+	return sb.String(), nil
+}
+
+// capitalise is for the guardians whose names start with "the", at the start of a sentence.
+func capitalise(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultCatchUpMax is the most time credited for a single absence, unless CatchUp.Max says otherwise
+const DefaultCatchUpMax = 24 * time.Hour
+
+// CatchUp credits players with some of the progress they missed while offline.
+type CatchUp struct {
+	// Share is the fraction of the time away that counts, e.g. 0.5 for half
+	Share float64
+	// Max is the most time that can be credited for a single absence. When zero, DefaultCatchUpMax.
+	Max time.Duration
+	// Tick is how much game time a tick of the game loop covers
+	Tick time.Duration
+}
+
+// Away is what happened while a player was offline.
+type Away struct {
+	Gone     time.Duration
+	Credited time.Duration
+	Levels   int
+	Items    []*Item
+}
+
+// catchUp plays the ticks the player is owed, without moving or fighting.
+// Must be called with w.mut held.
+func (w *World) catchUp(player *Player) *Away {
+	if w.CatchUp == nil || w.CatchUp.Tick <= 0 || player.Stats.LastSeen.IsZero() {
+		return nil
+	}
+
+	limit := w.CatchUp.Max
+	if limit <= 0 {
+		limit = DefaultCatchUpMax
+	}
+	gone := w.clock().Now().Sub(player.Stats.LastSeen)
+	credited := min(time.Duration(float64(gone)*w.CatchUp.Share), limit)
+	ticks := int(credited / w.CatchUp.Tick)
+	if ticks <= 0 {
+		return nil
+	}
+
+	away := &Away{Gone: gone, Credited: time.Duration(ticks) * w.CatchUp.Tick}
+	found := make(map[*Item]bool)
+	for i := 0; i < ticks; i++ {
+		away.Levels += player.Stats.Idle(w.CatchUp.Tick)
+		item, replaced := player.FindItem(w.rng(), w.terrain(player).ItemFind)
+		if item != nil {
+			found[item] = true
+			player.stash(replaced)
+		}
+	}
+
+	// Only report what they ended up wearing
+	for _, item := range player.Inventory {
+		if found[item] {
+			away.Items = append(away.Items, item)
+		}
+	}
+	return away
+}
+
+func (a *Away) ToString() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "While you were away for %s, you idled on for %s",
+		FormatDuration(a.Gone), FormatDuration(a.Credited))
+	if a.Levels == 0 && len(a.Items) == 0 {
+		sb.WriteString(", but nothing came of it.")
+		return sb.String()
+	}
+
+	sb.WriteString(":")
+	if a.Levels > 0 {
+		fmt.Fprintf(&sb, "\n  gained %d level(s)", a.Levels)
+	}
+	for _, i := range a.Items {
+		fmt.Fprintf(&sb, "\n  equipped a %s", i.ToString())
+	}
+	return sb.String()
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Class is the sin a player is damned for, and how it bends their luck.
+type Class struct {
+	Name        string
+	Description string
+
+	// ItemFind scales the chance of finding an item
+	ItemFind float64
+	// FightRoll scales every roll in the arena
+	FightRoll float64
+	// Blessing is the chance that a revelation is a blessing rather than a curse
+	Blessing float64
+	// Wander is the chance of taking a step each tick
+	Wander float64
+}
+
+// DefaultClass is given to anyone whose class isn't one of Classes.
+const DefaultClass = "Shade"
+
+var Classes = []Class{
+	{
+		Name:        DefaultClass,
+		Description: "A soul with no particular sin. Nothing helps, nothing hinders.",
+		ItemFind:    1.0,
+		FightRoll:   1.0,
+		Blessing:    0.5,
+		Wander:      1.0,
+	},
+	{
+		Name:        "Poet",
+		Description: "Walks in Virgil's footsteps. Favoured by the heavens, but no fighter.",
+		ItemFind:    1.0,
+		FightRoll:   0.85,
+		Blessing:    0.65,
+		Wander:      1.0,
+	},
+	{
+		Name:        "Heretic",
+		Description: "Denies the heavens and fights with a zealot's fury.",
+		ItemFind:    1.0,
+		FightRoll:   1.15,
+		Blessing:    0.4,
+		Wander:      1.0,
+	},
+	{
+		Name:        "Usurer",
+		Description: "Can't pass a trinket without pocketing it, or a fight without flinching.",
+		ItemFind:    1.3,
+		FightRoll:   0.9,
+		Blessing:    0.5,
+		Wander:      1.0,
+	},
+	{
+		Name:        "Glutton",
+		Description: "Lingers wherever they are, picking through what's left.",
+		ItemFind:    1.15,
+		FightRoll:   1.0,
+		Blessing:    0.5,
+		Wander:      0.5,
+	},
+	{
+		Name:        "Traitor",
+		Description: "Strikes first and never looks back. Heaven hasn't forgotten.",
+		ItemFind:    0.9,
+		FightRoll:   1.2,
+		Blessing:    0.35,
+		Wander:      1.0,
+	},
+}
+
+// FindClass looks up a class by name, ignoring case.
+func FindClass(name string) (*Class, bool) {
+	for i := range Classes {
+		if strings.EqualFold(Classes[i].Name, name) {
+			return &Classes[i], true
+		}
+	}
+	return nil, false
+}
+
+// ClassNames lists every class, for telling players what they can pick.
+func ClassNames() []string {
+	names := make([]string, 0, len(Classes))
+	for _, c := range Classes {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// ValidateClass returns the proper name of the class, or an error listing the choices.
+func ValidateClass(name string) (string, error) {
+	class, ok := FindClass(name)
+	if !ok {
+		return "", fmt.Errorf("Invalid class, choose one of: %s.", strings.Join(ClassNames(), ", "))
+	}
+	return class.Name, nil
+}
+
+// class is the player's class, or the default one if theirs is unknown.
+func (p *Player) class() *Class {
+	if class, ok := FindClass(p.Class); ok {
+		return class
+	}
+	class, _ := FindClass(DefaultClass)
+	return class
+}
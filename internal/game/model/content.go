@@ -0,0 +1,249 @@
+package model
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The default content pack. A pack is a directory of these files:
+//
+//	items.json      item names by slot, e.g. {"weapon": ["Blade of Acheron", ...], ...}
+//	blessings.json  revelations by circle, e.g. {"Limbo": ["A glimmer of divine truth...", ...], ...}
+//	curses.json     the same, for curses
+//	names.json      the Grammar names are built from
+//	monsters.json   monster names by circle, e.g. {"Limbo": ["Restless Shade", ...], ...}
+//	map.txt         the terrain and points of interest of each circle, see LoadMap
+//
+//go:embed content/*.json content/map.txt
+var defaultContent embed.FS
+
+const (
+	itemsFile     = "items.json"
+	blessingsFile = "blessings.json"
+	cursesFile    = "curses.json"
+	namesFile     = "names.json"
+	monstersFile  = "monsters.json"
+	mapFile       = "map.txt"
+)
+
+// Content is the text the game is dressed in.
+type Content struct {
+	Items     [9][]string
+	Blessings [WorldSize][]string
+	Curses    [WorldSize][]string
+	Names     Grammar
+	Monsters  [WorldSize][]string
+	Map       *Map
+}
+
+var content = mustLoadDefaultContent()
+
+func mustLoadDefaultContent() *Content {
+	c, err := loadContent(&Content{}, defaultContent, "content")
+	if err == nil {
+		err = c.Validate()
+	}
+	if err != nil {
+		panic("The default content pack is broken: " + err.Error())
+	}
+	return c
+}
+
+// LoadContent swaps in the content pack in dir, on top of the default one,
+// so a pack only needs the files, and the slots or circles in them, it changes.
+// Call it before the game starts.
+func LoadContent(dir string) error {
+	c, err := loadContent(content, os.DirFS(dir), ".")
+	if err != nil {
+		return err
+	}
+	err = c.Validate()
+	if err != nil {
+		return fmt.Errorf("Invalid content pack %s: %w", dir, err)
+	}
+	content = c
+	return nil
+}
+
+// loadContent reads the slots and circles in the pack over a copy of base.
+func loadContent(base *Content, fsys fs.FS, dir string) (*Content, error) {
+	c := *base
+
+	var items map[string][]string
+	found, err := readContentFile(fsys, dir, itemsFile, &items)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		for slot, names := range items {
+			class, err := ParseItemClass(slot)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", itemsFile, err)
+			}
+			c.Items[class] = names
+		}
+	}
+
+	for _, circles := range []struct {
+		file   string
+		circle *[WorldSize][]string
+	}{
+		{blessingsFile, &c.Blessings},
+		{cursesFile, &c.Curses},
+		{monstersFile, &c.Monsters},
+	} {
+		var byCircle map[string][]string
+		found, err := readContentFile(fsys, dir, circles.file, &byCircle)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		for name, lines := range byCircle {
+			circle := circleIndex(name)
+			if circle < 0 {
+				return nil, fmt.Errorf("%s: Unknown circle %s, choose one of: %s.",
+					circles.file, name, strings.Join(CircleNames[:], ", "))
+			}
+			circles.circle[circle] = lines
+		}
+	}
+
+	var names Grammar
+	found, err = readContentFile(fsys, dir, namesFile, &names)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if names.Common != nil {
+			c.Names.Common = names.Common
+		}
+		if names.Prefixes != nil {
+			c.Names.Prefixes = names.Prefixes
+		}
+		if names.Suffixes != nil {
+			c.Names.Suffixes = names.Suffixes
+		}
+		if names.Titles != nil {
+			c.Names.Titles = names.Titles
+		}
+	}
+
+	data, err := fs.ReadFile(fsys, filepath.ToSlash(filepath.Join(dir, mapFile)))
+	if err == nil {
+		c.Map, err = LoadMap(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", mapFile, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// readContentFile decodes a file of the pack into v, and reports whether it was there at all.
+func readContentFile(fsys fs.FS, dir, name string, v any) (bool, error) {
+	data, err := fs.ReadFile(fsys, filepath.ToSlash(filepath.Join(dir, name)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(v)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", name, err)
+	}
+	return true, nil
+}
+
+func circleIndex(name string) int {
+	for i, n := range CircleNames {
+		if strings.EqualFold(n, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Validate makes sure every item class has names, every circle has blessings, curses and monsters,
+// and there are words to name things with and a map to stand on.
+func (c *Content) Validate() error {
+	problems := make([]string, 0)
+	for class, names := range c.Items {
+		if !hasText(names) {
+			problems = append(problems, fmt.Sprintf("no names for %s items", ItemClass(class)))
+		}
+	}
+	for circle := range WorldSize {
+		if !hasText(c.Blessings[circle]) {
+			problems = append(problems, fmt.Sprintf("no blessings for %s", CircleNames[circle]))
+		}
+		if !hasText(c.Curses[circle]) {
+			problems = append(problems, fmt.Sprintf("no curses for %s", CircleNames[circle]))
+		}
+		if !hasText(c.Monsters[circle]) {
+			problems = append(problems, fmt.Sprintf("no monsters for %s", CircleNames[circle]))
+		}
+	}
+	if err := c.Names.Validate(); err != nil {
+		problems = append(problems, strings.TrimSuffix(err.Error(), "."))
+	}
+	if c.Map == nil {
+		problems = append(problems, "no map")
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, ", ") + ".")
+	}
+	return nil
+}
+
+// hasText is true for a non-empty list without blank lines.
+func hasText(lines []string) bool {
+	if len(lines) == 0 {
+		return false
+	}
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadedMap is the map of the content pack in use.
+func LoadedMap() *Map {
+	return content.Map
+}
+
+func GetItemName(rng *rand.Rand, i ItemClass) string {
+	if i < 0 || int(i) >= len(content.Items) {
+		return ""
+	}
+	names := content.Items[i]
+	return names[rng.IntN(len(names))]
+}
+
+func getBlessing(rng *rand.Rand, circle int) string {
+	return content.Blessings[circle][rng.IntN(len(content.Blessings[circle]))]
+}
+
+func getCurse(rng *rand.Rand, circle int) string {
+	return content.Curses[circle][rng.IntN(len(content.Curses[circle]))]
+}
+
+func getMonsterName(rng *rand.Rand, circle int) string {
+	return content.Monsters[circle][rng.IntN(len(content.Monsters[circle]))]
+}
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// CraftCooldown is how long a player has to wait between crafts,
+// so that idling stays the main way to get ahead.
+const CraftCooldown = 24 * time.Hour
+
+// Recipe is how crafting works in a circle: sacrifice Sacrifice items of the same class,
+// including the one being worn, to forge one Bonus levels above the best of them.
+type Recipe struct {
+	Sacrifice int
+	Bonus     int
+}
+
+// Recipes by circle. The deeper the circle, the more it asks, and the more it gives.
+var Recipes = [WorldSize]Recipe{
+	{Sacrifice: 3, Bonus: 1},
+	{Sacrifice: 3, Bonus: 1},
+	{Sacrifice: 4, Bonus: 2},
+	{Sacrifice: 4, Bonus: 2},
+	{Sacrifice: 4, Bonus: 3},
+	{Sacrifice: 5, Bonus: 3},
+	{Sacrifice: 5, Bonus: 4},
+	{Sacrifice: 5, Bonus: 5},
+	{Sacrifice: 5, Bonus: 6},
+}
+
+// Craft is a forging, from the items given up to the item made.
+type Craft struct {
+	Player     string
+	Circle     int
+	Sacrificed []*Item
+	Forged     *Item
+	Time       time.Time
+}
+
+// Craft sacrifices the player's worn item in a slot, and stashed items of the same class,
+// to forge a better one by the recipe of the circle they're in.
+// commit stores the craft, and the world only changes if it succeeds.
+func (w *World) Craft(name string, class ItemClass, commit func(*Craft) error) (*Craft, error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	player := w.player(name)
+	if player == nil {
+		return nil, errors.New("You need to be in the world to craft.")
+	}
+	now := w.clock().Now()
+	if ready := player.Stats.LastCraft.Add(CraftCooldown); now.Before(ready) {
+		return nil, fmt.Errorf("The forge is still cooling, try again in %s.", FormatDuration(ready.Sub(now)))
+	}
+
+	worn := player.Inventory[class]
+	if worn == nil {
+		return nil, fmt.Errorf("You have nothing in your %s slot to craft with.", class)
+	}
+	if worn.Escrow {
+		return nil, fmt.Errorf("Your %s is on offer in a trade.", worn.Name)
+	}
+
+	circle := player.Location.Y
+	recipe := Recipes[circle]
+	sacrificed := []*Item{worn}
+	for _, item := range player.Stash {
+		if len(sacrificed) == recipe.Sacrifice {
+			break
+		}
+		if item.Class == class {
+			sacrificed = append(sacrificed, item)
+		}
+	}
+	if len(sacrificed) < recipe.Sacrifice {
+		return nil, fmt.Errorf("%s asks for %d %s items, counting the one you're wearing, and you have %d.",
+			CircleNames[circle], recipe.Sacrifice, class, len(sacrificed))
+	}
+
+	best := 0
+	for _, item := range sacrificed {
+		best = max(best, item.ItemLevel)
+	}
+	craft := &Craft{
+		Player:     name,
+		Circle:     circle,
+		Sacrificed: sacrificed,
+		Forged: &Item{
+			Name:      forgedName(w.rng(), class),
+			Class:     class,
+			ItemLevel: best + recipe.Bonus,
+			Player:    name,
+		},
+		Time: now,
+	}
+
+	err := commit(craft)
+	if err != nil {
+		return nil, err
+	}
+
+	stash := make([]*Item, 0, len(player.Stash))
+	for _, item := range player.Stash {
+		if !craft.uses(item) {
+			stash = append(stash, item)
+		}
+	}
+	player.Stash = stash
+	player.Inventory[class] = craft.Forged
+	player.Stats.LastCraft = now
+
+	w.emit(Event{
+		Kind:    CraftEvent,
+		Player:  name,
+		Item:    craft.Forged.Name,
+		Level:   craft.Forged.ItemLevel,
+		Message: fmt.Sprintf("%s sacrificed %d items to the forges of %s and made a %s", name, len(sacrificed), CircleNames[circle], craft.Forged.ToString()),
+	})
+	return craft, nil
+}
+
+func (c *Craft) uses(item *Item) bool {
+	for _, i := range c.Sacrificed {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// forgedName puts the start of one item name together with the end of another, e.g.
+// "Band of the Damned Fate" and "Ring of the Silent Abyss" make "Hellforged Band of the Silent Abyss".
+func forgedName(rng *rand.Rand, class ItemClass) string {
+	head, _, found := strings.Cut(GetItemName(rng, class), " of ")
+	if !found {
+		return "Hellforged " + head
+	}
+	for range 10 {
+		if _, tail, found := strings.Cut(GetItemName(rng, class), " of "); found {
+			return fmt.Sprintf("Hellforged %s of %s", head, tail)
+		}
+	}
+	return "Hellforged " + head
+}
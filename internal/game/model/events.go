@@ -0,0 +1,96 @@
+package model
+
+import (
+	"log"
+	"time"
+)
+
+type EventKind string
+
+const (
+	LevelUpEvent     EventKind = "levelup"
+	ItemEvent        EventKind = "item"
+	FightEvent       EventKind = "fight"
+	RevelationEvent  EventKind = "revelation"
+	AchievementEvent EventKind = "achievement"
+	TradeEvent       EventKind = "trade"
+	CraftEvent       EventKind = "craft"
+	BossEvent        EventKind = "boss"
+	LandmarkEvent    EventKind = "landmark"
+)
+
+var EventKinds = []EventKind{LevelUpEvent, ItemEvent, FightEvent, RevelationEvent, AchievementEvent, TradeEvent, CraftEvent, BossEvent, LandmarkEvent}
+
+// Event is something that happened in the world worth telling others about.
+type Event struct {
+	// Id is set once the event has been stored in the history
+	Id       int64     `json:"id,omitempty"`
+	Kind     EventKind `json:"kind"`
+	Player   string    `json:"player"`
+	Opponent string    `json:"opponent,omitempty"`
+	Winner   string    `json:"winner,omitempty"`
+	// Both rolls of a fight
+	PlayerRoll   int `json:"playerRoll,omitempty"`
+	OpponentRoll int `json:"opponentRoll,omitempty"`
+	// Names of the item found, and the one it replaced
+	Item     string `json:"item,omitempty"`
+	Replaced string `json:"replaced,omitempty"`
+	// Curse is set on revelations that were curses rather than blessings
+	Curse bool `json:"curse,omitempty"`
+	// Level is the headline number of the event:
+	// the new level on a level up, the item level of a found item,
+	// the winning roll of a fight, and the guardian's level in a boss fight.
+	Level   int       `json:"level"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// Subscribe returns a channel that receives every event emitted by the world.
+// Events are dropped for subscribers that fall more than buffer events behind.
+func (w *World) Subscribe(buffer int) <-chan Event {
+	w.subMut.Lock()
+	defer w.subMut.Unlock()
+
+	ch := make(chan Event, buffer)
+	w.subscribers = append(w.subscribers, ch)
+	return ch
+}
+
+func (w *World) emit(e Event) {
+	e.Time = w.clock().Now()
+	log.Println(e.Message)
+	w.remember(e)
+	if w.History {
+		w.unsaved = append(w.unsaved, e)
+	}
+	w.countAchievements(e)
+
+	w.subMut.Lock()
+	defer w.subMut.Unlock()
+	for _, ch := range w.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// SaveEvents hands every event since the last save to save, oldest first, when keeping the History.
+// The world carries on while they're saved, and if saving fails they're kept for the next try.
+func (w *World) SaveEvents(save func([]Event) error) error {
+	w.mut.Lock()
+	events := w.unsaved
+	w.unsaved = nil
+	w.mut.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+	err := save(events)
+	if err != nil {
+		w.mut.Lock()
+		w.unsaved = append(events, w.unsaved...)
+		w.mut.Unlock()
+	}
+	return err
+}
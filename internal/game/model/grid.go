@@ -0,0 +1,115 @@
+package model
+
+const (
+	// DefaultCircleWidth is how many cells wide each circle starts out
+	DefaultCircleWidth = 9
+	// MaxCircleWidth is as wide as a circle can grow, which gives the world room for tens of thousands
+	MaxCircleWidth = 4096
+)
+
+// Grid is where everyone stands: a row of cells for each circle.
+// Each circle has its own width, and widens as it fills up.
+type Grid struct {
+	rows [WorldSize][]*Player
+	// occupied counts the taken cells of each circle, so a full one is spotted without a scan
+	occupied [WorldSize]int
+}
+
+// NewGrid makes a grid with the given circle widths. Widths under 1 get DefaultCircleWidth.
+func NewGrid(widths [WorldSize]int) *Grid {
+	g := &Grid{}
+	for circle, width := range widths {
+		if width < 1 {
+			width = DefaultCircleWidth
+		}
+		g.rows[circle] = make([]*Player, min(width, MaxCircleWidth))
+	}
+	return g
+}
+
+func (g *Grid) Width(circle int) int {
+	return len(g.rows[circle])
+}
+
+func (g *Grid) Widths() [WorldSize]int {
+	widths := [WorldSize]int{}
+	for circle, row := range g.rows {
+		widths[circle] = len(row)
+	}
+	return widths
+}
+
+func (g *Grid) Contains(c Coordinates) bool {
+	return c.Y >= 0 && c.Y < WorldSize && c.X >= 0 && c.X < len(g.rows[c.Y])
+}
+
+// At is whoever stands on a cell, or nil if it's empty or off the grid.
+func (g *Grid) At(c Coordinates) *Player {
+	if !g.Contains(c) {
+		return nil
+	}
+	return g.rows[c.Y][c.X]
+}
+
+// put stands the player on an empty cell.
+func (g *Grid) put(c Coordinates, player *Player) {
+	g.rows[c.Y][c.X] = player
+	g.occupied[c.Y]++
+	player.Location.X = c.X
+	player.Location.Y = c.Y
+}
+
+// clear empties a cell, if the player is still on it.
+func (g *Grid) clear(player *Player) {
+	c := *player.Location
+	if g.At(c) == player {
+		g.rows[c.Y][c.X] = nil
+		g.occupied[c.Y]--
+	}
+}
+
+func (g *Grid) move(player *Player, to Coordinates) {
+	g.clear(player)
+	g.put(to, player)
+}
+
+// neighbors are the cells a step away. Circles can differ in width,
+// so a step up or down lands at the same point of the way around the next circle.
+func (g *Grid) neighbors(c Coordinates) []Coordinates {
+	coords := make([]Coordinates, 0, 4)
+	for _, dir := range [...]Coordinates{Up, Down, Left, Right} {
+		next := Coordinates{X: c.X + dir.X, Y: c.Y + dir.Y}
+		if dir.Y != 0 && next.Y >= 0 && next.Y < WorldSize {
+			next.X = c.X * g.Width(next.Y) / g.Width(c.Y)
+		}
+		if g.Contains(next) {
+			coords = append(coords, next)
+		}
+	}
+	return coords
+}
+
+// empty finds a free cell in the circle, widening it if it's full.
+// It fails only when the circle can't grow any further.
+func (g *Grid) empty(circle int) (Coordinates, bool) {
+	if g.occupied[circle] >= g.Width(circle) && !g.grow(circle) {
+		return Coordinates{}, false
+	}
+	for x, cell := range g.rows[circle] {
+		if cell == nil {
+			return Coordinates{X: x, Y: circle}, true
+		}
+	}
+	return Coordinates{}, false
+}
+
+// grow widens a circle by half.
+func (g *Grid) grow(circle int) bool {
+	width := g.Width(circle)
+	if width >= MaxCircleWidth {
+		return false
+	}
+	grown := min(width+max(1, width/2), MaxCircleWidth)
+	g.rows[circle] = append(g.rows[circle], make([]*Player, grown-width)...)
+	return true
+}
@@ -2,6 +2,8 @@ package model
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"strings"
 )
 
 type ItemClass int
@@ -18,20 +20,51 @@ const (
 	Weapon
 )
 
+var itemClassNames = [...]string{
+	Head:     "head",
+	Torso:    "torso",
+	Legs:     "legs",
+	Arms:     "arms",
+	Gloves:   "gloves",
+	Boots:    "boots",
+	Necklace: "necklace",
+	Ring:     "ring",
+	Weapon:   "weapon",
+}
+
+func (c ItemClass) String() string {
+	if c < 0 || int(c) >= len(itemClassNames) {
+		return fmt.Sprintf("ItemClass(%d)", int(c))
+	}
+	return itemClassNames[c]
+}
+
+// ParseItemClass reads a slot name, e.g. "weapon".
+func ParseItemClass(name string) (ItemClass, error) {
+	for i, n := range itemClassNames {
+		if strings.EqualFold(n, name) {
+			return ItemClass(i), nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown slot %s, choose one of: %s.", name, strings.Join(itemClassNames[:], ", "))
+}
+
 type Item struct {
 	Id        string
 	Name      string
 	ItemLevel int
 	Class     ItemClass
 	Player    string
+	// Escrow is set while the item is offered in a trade, so nothing replaces it
+	Escrow bool
 }
 
 func (i Item) ToString() string {
 	return fmt.Sprintf("level %d %s", i.ItemLevel, i.Name)
 }
 
-func createItem(itemClass ItemClass, itemLevel int) *Item {
-	name := GetItemName(itemClass)
+func createItem(rng *rand.Rand, itemClass ItemClass, itemLevel int) *Item {
+	name := ItemName(rng, itemClass, itemLevel, RollRarity(rng, itemLevel))
 	return &Item{
 		Name:      name,
 		Class:     ItemClass(itemClass),
@@ -0,0 +1,155 @@
+package model
+
+import "fmt"
+
+const (
+	// MonstersPerCircle is how many monsters can roam a circle at once,
+	// for every DefaultCircleWidth cells of it
+	MonstersPerCircle = 2
+	// MonsterSpawnChance is the chance each tick that a circle short of monsters gets a new one
+	MonsterSpawnChance = 0.05
+	// MonsterRoamChance is the chance each tick that a monster takes a step
+	MonsterRoamChance = 0.5
+	// MonsterWinBonus is the percentage of the time to level taken off for slaying a monster,
+	// on top of the usual for winning a fight
+	MonsterWinBonus = 1.0
+)
+
+// MonsterLevel is how strong the monsters of a circle are. The deeper, the stronger.
+func MonsterLevel(circle int) int {
+	return 3 + circle*6
+}
+
+// newMonster makes a monster for the circle. It wears a full set of items of about its level,
+// which it fights with, and one of which it drops when slain.
+// Must be called with w.mut held.
+func (w *World) newMonster(circle int) *Player {
+	rng := w.rng()
+	rarity := RollRarity(rng, MonsterLevel(circle))
+	level := max(1, MonsterLevel(circle)+rng.IntN(5)-2+2*int(rarity))
+
+	monster := &Player{
+		Name:    Name(rng, getMonsterName(rng, circle), level, rarity),
+		Class:   DefaultClass,
+		Stats:   &Stats{Level: level, TimeToLevel: TimeForLevel(level)},
+		Monster: true,
+	}
+	for class := range monster.Inventory {
+		monster.Inventory[class] = createItem(rng, ItemClass(class), rng.IntN(level)+1)
+	}
+	return monster
+}
+
+// Haunt spawns monsters into the circles that are short of them, and lets the ones about roam.
+func (w *World) Haunt() {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	count := [WorldSize]int{}
+	for _, monster := range w.Monsters {
+		count[monster.Location.Y]++
+	}
+	for circle := range WorldSize {
+		limit := MonstersPerCircle * max(1, w.grid().Width(circle)/DefaultCircleWidth)
+		if count[circle] < limit && w.rng().Float64() < MonsterSpawnChance {
+			w.spawn(circle)
+		}
+	}
+
+	for _, monster := range w.Monsters {
+		if w.rng().Float64() < MonsterRoamChance {
+			w.roam(monster)
+		}
+	}
+}
+
+// spawn puts a new monster on an empty cell of the circle, if there is one.
+// Must be called with w.mut held.
+func (w *World) spawn(circle int) {
+	grid := w.grid()
+	// A few tries at a random cell, rather than a scan of a wide circle
+	for range 4 {
+		at := Coordinates{X: w.rng().IntN(grid.Width(circle)), Y: circle}
+		if grid.At(at) != nil {
+			continue
+		}
+		monster := w.newMonster(circle)
+		monster.Location = &Coordinates{}
+		grid.put(at, monster)
+		w.Monsters = append(w.Monsters, monster)
+		return
+	}
+}
+
+// roam moves a monster a step along its circle. Monsters never leave the circle they spawned in.
+// Must be called with w.mut held.
+func (w *World) roam(monster *Player) {
+	steps := make([]Coordinates, 0, 2)
+	for _, c := range w.getEmptyNeighborCoords(monster.Location) {
+		if c.Y == monster.Location.Y {
+			steps = append(steps, c)
+		}
+	}
+	if len(steps) == 0 {
+		return
+	}
+	w.grid().move(monster, steps[w.rng().IntN(len(steps))])
+}
+
+// banish takes a monster out of the world.
+// Must be called with w.mut held.
+func (w *World) banish(monster *Player) {
+	monsters := make([]*Player, 0, len(w.Monsters))
+	for _, m := range w.Monsters {
+		if m != monster {
+			monsters = append(monsters, m)
+		}
+	}
+	w.Monsters = monsters
+	w.grid().clear(monster)
+}
+
+// slay rewards the player for beating a monster, which leaves the world and drops one of its items.
+// Must be called with w.mut held.
+func (w *World) slay(player, monster *Player, e *Event) {
+	w.banish(monster)
+	player.Stats.Bonus(MonsterWinBonus)
+
+	drop := monster.Inventory[w.rng().IntN(len(monster.Inventory))]
+	replaced, received := player.receive(drop)
+	e.Item = drop.Name
+	if replaced != nil {
+		e.Replaced = replaced.Name
+	}
+	e.Message += fmt.Sprintf(" The %s dropped a %s%s.", monster.Name, drop.ToString(), received)
+}
+
+// receive hands the player an item they won. They equip it if it beats what they're wearing,
+// and stash it if not, while there's room. It returns the item it replaced, if any,
+// and says what became of it all, e.g. ", which they equipped, stashing their ...".
+func (p *Player) receive(item *Item) (*Item, string) {
+	item.Player = p.Name
+
+	var message string
+	stashed := item
+	worn := p.Inventory[item.Class]
+	if worn == nil || (!worn.Escrow && worn.ItemLevel < item.ItemLevel) {
+		p.Inventory[item.Class] = item
+		stashed = worn
+		message = ", which they equipped"
+		if worn != nil {
+			message += fmt.Sprintf(", stashing their %s", worn.ToString())
+		}
+	} else if len(p.Stash) < StashSize {
+		worn = nil
+		message = ", which they stashed"
+	} else {
+		// Not worth throwing anything out of a full stash for
+		worn, stashed = nil, nil
+		message = ", which they left where it fell"
+	}
+	if discarded := p.stash(stashed); discarded != nil {
+		message += fmt.Sprintf(" and discarding their %s", discarded.ToString())
+	}
+	return worn, message
+}
@@ -0,0 +1,215 @@
+package model
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+const (
+	// GoalRange is how many cells either way along their circle a player looks for something to head for
+	GoalRange = 8
+	// PathSearchLimit is how many cells a search for a path looks at before giving up
+	PathSearchLimit = 64
+)
+
+// GoalKind is what a player is heading for.
+type GoalKind int
+
+const (
+	// Idle players have nothing in mind, and wander at random
+	Idle GoalKind = iota
+	// Pilgrimage is for a shrine they can kneel at
+	Pilgrimage
+	// Hunt is for a monster they're a match for
+	Hunt
+	// Rivalry is for another sinner they're a match for
+	Rivalry
+)
+
+var goalKindNames = []string{"idle", "pilgrimage", "hunt", "rivalry"}
+
+func (g GoalKind) String() string {
+	if g < 0 || int(g) >= len(goalKindNames) {
+		return fmt.Sprintf("GoalKind(%d)", int(g))
+	}
+	return goalKindNames[g]
+}
+
+// Goal is where a player is heading, and why.
+type Goal struct {
+	Kind GoalKind
+	// At is the cell to reach, or for a hunt or rivalry, the cell to get next to
+	At Coordinates
+	// Target is who's being hunted or challenged
+	Target *Player
+}
+
+// FindPath is the cheapest walk over empty cells from one cell to another, not counting the first,
+// or to any cell next to it when adjacent is set. Each step costs more the slower the terrain
+// it lands on is to leave. It's nil when the way is blocked, or too long to find within PathSearchLimit.
+func FindPath(g *Grid, m *Map, from, to Coordinates, adjacent bool) []Coordinates {
+	arrived := func(c Coordinates) bool {
+		if adjacent {
+			for _, n := range g.neighbors(c) {
+				if n == to {
+					return true
+				}
+			}
+			return false
+		}
+		return c == to
+	}
+	if arrived(from) {
+		return []Coordinates{}
+	}
+
+	// The estimate never overshoots. No step costs under 1, and counted in cells of the narrowest circle
+	// in between, a step along a circle goes at most one cell around, and a step up or down slips by under one
+	estimate := func(c Coordinates) float64 {
+		climb := abs(c.Y - to.Y)
+		narrowest := g.Width(c.Y)
+		for y := min(c.Y, to.Y); y <= max(c.Y, to.Y); y++ {
+			narrowest = min(narrowest, g.Width(y))
+		}
+		around := math.Abs(float64(c.X)/float64(g.Width(c.Y))-float64(to.X)/float64(g.Width(to.Y))) * float64(narrowest)
+		d := float64(climb) + max(around-float64(climb), 0)
+		if adjacent {
+			d--
+		}
+		return max(d, 0)
+	}
+
+	cost := map[Coordinates]float64{from: 0}
+	came := map[Coordinates]Coordinates{}
+	open := &pathQueue{{at: from, priority: estimate(from)}}
+	for searched := 0; open.Len() > 0 && searched < PathSearchLimit; searched++ {
+		current := heap.Pop(open).(pathNode)
+		if current.priority > cost[current.at]+estimate(current.at) {
+			// A cheaper way here was already found
+			continue
+		}
+		if arrived(current.at) {
+			path := []Coordinates{current.at}
+			for at := current.at; came[at] != from; {
+				at = came[at]
+				path = append(path, at)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		for _, next := range g.neighbors(current.at) {
+			if g.At(next) != nil {
+				continue
+			}
+			nextCost := cost[current.at] + stepCost(m.Tile(next).Terrain)
+			if known, ok := cost[next]; ok && known <= nextCost {
+				continue
+			}
+			cost[next] = nextCost
+			came[next] = current.at
+			heap.Push(open, pathNode{at: next, priority: nextCost + estimate(next)})
+		}
+	}
+	return nil
+}
+
+// stepCost is how long a step onto the terrain takes, as it's that much less likely to be stepped off.
+func stepCost(t *Terrain) float64 {
+	return 1 / t.Wander
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+type pathNode struct {
+	at       Coordinates
+	priority float64
+}
+
+// pathQueue is a heap of the cells a path search has yet to look at, the most promising first.
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x any)        { *q = append(*q, x.(pathNode)) }
+func (q *pathQueue) Pop() any {
+	old := *q
+	node := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return node
+}
+
+// goal picks what the player heads for: a shrine they can kneel at, then, if they're equipped,
+// a monster or a sinner no better equipped than they are, all along their own circle and within GoalRange.
+// Must be called with w.mut held.
+func (w *World) goal(player *Player) Goal {
+	grid := w.grid()
+	worldMap := w.worldMap()
+	now := w.clock().Now()
+	at := *player.Location
+	itemLevel := player.ItemLevel()
+
+	monster, rival := Goal{Kind: Idle}, Goal{Kind: Idle}
+	// Nearest first, looking either way
+	for d := 1; d <= GoalRange; d++ {
+		for _, x := range [2]int{at.X - d, at.X + d} {
+			c := Coordinates{X: x, Y: at.Y}
+			if !grid.Contains(c) {
+				continue
+			}
+			occupant := grid.At(c)
+			if occupant == nil {
+				if worldMap.Tile(c).Point == Shrine {
+					last, ok := w.landmarks[landmarkVisit{player: player.Name, point: Shrine}]
+					if !ok || !now.Before(last.Add(ShrineCooldown)) {
+						return Goal{Kind: Pilgrimage, At: c}
+					}
+				}
+				continue
+			}
+			// Item levels take some adding up, so only work them out for a better goal than the one in hand
+			if itemLevel == 0 || monster.Kind != Idle || (!occupant.Monster && rival.Kind != Idle) {
+				continue
+			}
+			if level := occupant.ItemLevel(); level == 0 || level > itemLevel {
+				continue
+			}
+			if occupant.Monster {
+				monster = Goal{Kind: Hunt, At: c, Target: occupant}
+			} else {
+				rival = Goal{Kind: Rivalry, At: c, Target: occupant}
+			}
+		}
+	}
+
+	if monster.Kind != Idle {
+		return monster
+	}
+	return rival
+}
+
+// seek is the player's next step towards their goal, which is where they are if they've reached it.
+// It's false if they have none, or can't find a way there, and should wander instead.
+// Must be called with w.mut held.
+func (w *World) seek(player *Player) (Coordinates, bool) {
+	goal := w.goal(player)
+	if goal.Kind == Idle {
+		return Coordinates{}, false
+	}
+	path := FindPath(w.grid(), w.worldMap(), *player.Location, goal.At, goal.Target != nil)
+	if path == nil {
+		return Coordinates{}, false
+	}
+	if len(path) == 0 {
+		return *player.Location, true
+	}
+	return path[0], true
+}
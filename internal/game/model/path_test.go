@@ -0,0 +1,93 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// plain is a map that's ground all over.
+var plain = &Map{}
+
+func coords(xys ...int) []Coordinates {
+	path := make([]Coordinates, 0, len(xys)/2)
+	for i := 0; i < len(xys); i += 2 {
+		path = append(path, Coordinates{X: xys[i], Y: xys[i+1]})
+	}
+	return path
+}
+
+func block(g *Grid, cells ...Coordinates) {
+	for _, c := range cells {
+		g.put(c, &Player{Name: "boulder", Location: &Coordinates{}})
+	}
+}
+
+func TestFindPathWalksStraightAlongACircle(t *testing.T) {
+	g := NewGrid([WorldSize]int{})
+	got := FindPath(g, plain, Coordinates{X: 1, Y: 2}, Coordinates{X: 5, Y: 2}, false)
+	if want := coords(2, 2, 3, 2, 4, 2, 5, 2); !slices.Equal(got, want) {
+		t.Fatalf("path = %v, want %v", got, want)
+	}
+}
+
+func TestFindPathBlocked(t *testing.T) {
+	g := NewGrid([WorldSize]int{})
+	block(g, Coordinates{X: 1, Y: 0}, Coordinates{X: 0, Y: 1})
+	if got := FindPath(g, plain, Coordinates{X: 0, Y: 0}, Coordinates{X: 5, Y: 0}, false); got != nil {
+		t.Fatalf("path = %v, want nil for a walled in start", got)
+	}
+}
+
+func TestFindPathToBesideTheGoal(t *testing.T) {
+	g := NewGrid([WorldSize]int{})
+	// Someone stands on the goal, as they do on a hunt
+	block(g, Coordinates{X: 3, Y: 0})
+
+	got := FindPath(g, plain, Coordinates{X: 0, Y: 0}, Coordinates{X: 3, Y: 0}, true)
+	if want := coords(1, 0, 2, 0); !slices.Equal(got, want) {
+		t.Fatalf("path = %v, want %v", got, want)
+	}
+
+	got = FindPath(g, plain, Coordinates{X: 2, Y: 0}, Coordinates{X: 3, Y: 0}, true)
+	if got == nil || len(got) != 0 {
+		t.Fatalf("path = %#v, want an empty path when already beside the goal", got)
+	}
+}
+
+func TestFindPathGoesAroundSlowTerrain(t *testing.T) {
+	m, err := LoadMap(strings.NewReader(strings.Repeat(".........\n", WorldSize-1) + ".~~~.....\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGrid(m.Widths())
+	last := WorldSize - 1
+
+	// Across the river is 1 + 3 steps of 2, and round it through the circle above is 6 steps of 1
+	got := FindPath(g, m, Coordinates{X: 0, Y: last}, Coordinates{X: 4, Y: last}, false)
+	if len(got) != 6 || got[len(got)-1] != (Coordinates{X: 4, Y: last}) {
+		t.Fatalf("path = %v, want 6 steps round the river", got)
+	}
+	for _, c := range got {
+		if m.Tile(c).Terrain == Styx {
+			t.Fatalf("path = %v wades through the river at %v", got, c)
+		}
+	}
+}
+
+func TestFindPathGivesUpPastTheSearchLimit(t *testing.T) {
+	// Every circle as wide, so there's no cutting across a narrower one
+	widths := [WorldSize]int{}
+	for circle := range widths {
+		widths[circle] = 4 * PathSearchLimit
+	}
+	g := NewGrid(widths)
+	from := Coordinates{X: 0, Y: 0}
+
+	if got := FindPath(g, plain, from, Coordinates{X: PathSearchLimit / 2, Y: 0}, false); len(got) != PathSearchLimit/2 {
+		t.Fatalf("path = %v, want %d steps", got, PathSearchLimit/2)
+	}
+	if got := FindPath(g, plain, from, Coordinates{X: 2 * PathSearchLimit, Y: 0}, false); got != nil {
+		t.Fatalf("found a %d step path, want nil past PathSearchLimit", len(got))
+	}
+}
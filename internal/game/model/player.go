@@ -2,10 +2,10 @@ package model
 
 import (
 	"fmt"
-	"log"
 	"math/rand/v2"
 	"strings"
 	"text/tabwriter"
+	"time"
 )
 
 type Player struct {
@@ -14,7 +14,44 @@ type Player struct {
 	Class     string
 	Stats     *Stats
 	Inventory [9]*Item
-	Location  *Coordinates
+	// Stash holds the items the player has replaced, newest first
+	Stash    []*Item
+	Location *Coordinates
+	// Achievement id -> progress towards it
+	Achievements map[string]*Progress
+	// Monster is set on the creatures roaming the circles, who are nobody's character
+	Monster bool
+}
+
+// copy is a deep copy of the player, that nothing the world does to them can reach.
+// Their items and progress are each copied into one block, as there are a lot of players to copy every tick.
+func (p *Player) copy() *Player {
+	c := *p
+	stats := *p.Stats
+	c.Stats = &stats
+	location := *p.Location
+	c.Location = &location
+
+	items := make([]Item, 0, len(p.Inventory)+len(p.Stash))
+	for class, item := range p.Inventory {
+		if item != nil {
+			items = append(items, *item)
+			c.Inventory[class] = &items[len(items)-1]
+		}
+	}
+	c.Stash = make([]*Item, len(p.Stash))
+	for i, item := range p.Stash {
+		items = append(items, *item)
+		c.Stash[i] = &items[len(items)-1]
+	}
+
+	progress := make([]Progress, 0, len(p.Achievements))
+	c.Achievements = make(map[string]*Progress, len(p.Achievements))
+	for id, pr := range p.Achievements {
+		progress = append(progress, *pr)
+		c.Achievements[id] = &progress[len(progress)-1]
+	}
+	return &c
 }
 
 type User struct {
@@ -25,7 +62,14 @@ type User struct {
 	Online   bool
 }
 
-func (p Player) ItemLevel() int {
+// ItemLevel is the player's effective item level, with their set bonuses.
+func (p *Player) ItemLevel() int {
+	base := p.BaseItemLevel()
+	return base + int(float64(base)*p.setBonus().ItemLevel/100)
+}
+
+// BaseItemLevel is the sum of the levels of the items the player is wearing.
+func (p *Player) BaseItemLevel() int {
 	sum := 0
 	for _, i := range p.Inventory {
 		if i == nil {
@@ -50,22 +94,24 @@ If an item is found with the 20% base chance, for a level 20 player,
 		Level 15: 2.90%
 		Level 20: 1.09%
 */
-func (p *Player) FindItem() {
+// FindItem returns the newly equipped item, or nil if nothing better was found,
+// along with the item it replaced, if any. luck scales the chance, for the ground the player is searching.
+func (p *Player) FindItem(rng *rand.Rand, luck float64) (*Item, *Item) {
 	// Base chance of finding an item
-	playerRollToFindTheItem := float64(p.Stats.Level()+2) / 100.0
+	playerRollToFindTheItem := float64(p.Stats.Level+2) / 100.0 * p.class().ItemFind * (1 + p.setBonus().ItemFind/100) * luck
 
 	// Random chance to find an item
-	chanceToFindTheItem := rand.Float64()
+	chanceToFindTheItem := rng.Float64()
 	if chanceToFindTheItem > playerRollToFindTheItem {
-		return
+		return nil, nil
 	}
 
 	// Item class
-	itemClass := rand.IntN(9)
+	itemClass := rng.IntN(9)
 
 	// Randomly determine item level with bias towards lower levels
-	maxLevel := p.Stats.Level() + 2
-	itemLevel := weightedRandomItemLevel(maxLevel)
+	maxLevel := p.Stats.Level + 2
+	itemLevel := weightedRandomItemLevel(rng, maxLevel)
 
 	// Item level adjustment
 	k := 2.0 // the scale factor for difficulty
@@ -74,24 +120,30 @@ func (p *Player) FindItem() {
 	// Calculate final chance of getting this item level
 	finalChance := playerRollToFindTheItem * itemLevelChance
 
-	if rand.Float64() > finalChance {
-		return
+	if rng.Float64() > finalChance {
+		return nil, nil
 	}
 
 	// Check if the found item is worse than existing one
 	if p.Inventory[itemClass] != nil && p.Inventory[itemClass].ItemLevel > itemLevel {
-		return
+		return nil, nil
+	}
+
+	// Don't pull an item out from under a trade
+	if p.Inventory[itemClass] != nil && p.Inventory[itemClass].Escrow {
+		return nil, nil
 	}
 
 	// Create and add the new item
-	newItem := createItem(ItemClass(itemClass), itemLevel)
+	newItem := createItem(rng, ItemClass(itemClass), itemLevel)
 	newItem.Player = p.Name
+	replaced := p.Inventory[itemClass]
 	p.Inventory[itemClass] = newItem
-	log.Println(p.Name, "equipped a", newItem.ToString())
+	return newItem, replaced
 }
 
 // weightedRandomItemLevel generates a random item level with bias towards lower levels
-func weightedRandomItemLevel(maxLevel int) int {
+func weightedRandomItemLevel(rng *rand.Rand, maxLevel int) int {
 	// Create a weighted distribution
 	weights := make([]float64, maxLevel+1)
 	totalWeight := 0.0
@@ -103,7 +155,7 @@ func weightedRandomItemLevel(maxLevel int) int {
 	}
 
 	// Randomly select an item level based on weights
-	roll := rand.Float64() * totalWeight
+	roll := rng.Float64() * totalWeight
 	for level, weight := range weights {
 		if roll < weight {
 			return level
@@ -119,14 +171,19 @@ func (p *Player) ToString() string {
 	var sb strings.Builder
 	tw := tabwriter.NewWriter(&sb, 4, 1, 1, ' ', 0)
 	fmt.Fprintf(tw, "Name: %s\n", p.Name)
-	fmt.Fprintf(tw, "Class: %s\n", p.Class)
+	if title := p.Title(); title != "" {
+		fmt.Fprintf(tw, "Title: %s\n", title)
+	}
+	fmt.Fprintf(tw, "Class: %s (%s)\n", p.Class, p.class().Description)
 	fmt.Fprintf(tw, "Item level: %d\n", p.ItemLevel())
-	fmt.Fprintf(tw, "Experience: %d\n", p.Stats.Xp)
-	fmt.Fprintf(tw, "Level: %d\n", p.Stats.Level())
-	fmt.Fprintf(tw, "Next level: %d\n", p.Stats.UntilNextLevel())
+	if base := p.BaseItemLevel(); base != p.ItemLevel() {
+		fmt.Fprintf(tw, "Base item level: %d\n", base)
+	}
+	fmt.Fprintf(tw, "Level: %d\n", p.Stats.Level)
+	fmt.Fprintf(tw, "Next level: level %d in %s\n", p.Stats.Level+1, FormatDuration(p.Stats.TimeToLevel))
 	fmt.Fprintf(tw, "Location: (%d,%d)\n", p.Location.X, p.Location.Y)
 	fmt.Fprintf(tw, "Id: %s\n", p.Id)
-	fmt.Fprintf(tw, "Created: %s\n", p.Stats.Created)
+	fmt.Fprintf(tw, "Created: %s\n", p.Stats.Created.Format(time.DateTime))
 	fmt.Fprintf(tw, "Inventory:\n")
 	for _, i := range p.Inventory {
 		if i == nil {
@@ -134,6 +191,18 @@ func (p *Player) ToString() string {
 		}
 		fmt.Fprintf(tw, "%s (%d)\n", i.Name, i.ItemLevel)
 	}
+	fmt.Fprintf(tw, "Stash:\n")
+	for _, line := range p.CompareStash() {
+		fmt.Fprintf(tw, "%s\n", line)
+	}
+	fmt.Fprintf(tw, "Sets:\n")
+	for _, s := range p.Sets() {
+		fmt.Fprintf(tw, "%s\n", s.ToString())
+	}
+	fmt.Fprintf(tw, "Achievements:\n")
+	for _, a := range p.Unlocked() {
+		fmt.Fprintf(tw, "%s: %s\n", a.Name, a.Description)
+	}
 	tw.Flush()
 	return sb.String()
 }
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ItemSet is a group of themed items, one for each slot.
+// Wearing enough pieces of a set at once earns its bonuses.
+type ItemSet struct {
+	Name string
+	// Pieces is the name of the set's item for each ItemClass
+	Pieces [9]string
+	// Bonuses from the fewest pieces to the most. Only the best one reached applies.
+	Bonuses []SetBonus
+}
+
+type SetBonus struct {
+	Pieces int
+	// ItemLevel is the percentage added to the player's item level, which they fight with
+	ItemLevel float64
+	// ItemFind is the percentage added to the chance of finding an item
+	ItemFind float64
+}
+
+func (b SetBonus) ToString() string {
+	bonuses := make([]string, 0, 2)
+	if b.ItemLevel > 0 {
+		bonuses = append(bonuses, fmt.Sprintf("+%g%% item level", b.ItemLevel))
+	}
+	if b.ItemFind > 0 {
+		bonuses = append(bonuses, fmt.Sprintf("+%g%% item find", b.ItemFind))
+	}
+	return strings.Join(bonuses, ", ")
+}
+
+var ItemSets = []ItemSet{
+	{
+		Name: "Geryon",
+		Pieces: [9]string{
+			Head:     "Mask of Geryon",
+			Torso:    "Scaled Hauberk of Geryon",
+			Legs:     "Serpent Greaves of Geryon",
+			Arms:     "Painted Vambraces of Geryon",
+			Gloves:   "Clawed Gauntlets of Geryon",
+			Boots:    "Boots of Geryon's Descent",
+			Necklace: "Amulet of Geryon",
+			Ring:     "Ring of Geryon's Coils",
+			Weapon:   "Winged Blade of Geryon",
+		},
+		Bonuses: []SetBonus{
+			{Pieces: 3, ItemFind: 10},
+			{Pieces: 6, ItemFind: 25},
+			{Pieces: 9, ItemFind: 50, ItemLevel: 10},
+		},
+	},
+	{
+		Name: "Minos",
+		Pieces: [9]string{
+			Head:     "Helm of Minos",
+			Torso:    "Judge's Mantle of Minos",
+			Legs:     "Greaves of Minos",
+			Arms:     "Coiled Vambraces of Minos",
+			Gloves:   "Gauntlets of Minos",
+			Boots:    "Sabatons of Minos",
+			Necklace: "Pendant of Minos",
+			Ring:     "Signet of Minos",
+			Weapon:   "Searing Whip of Minos",
+		},
+		Bonuses: []SetBonus{
+			{Pieces: 3, ItemLevel: 5},
+			{Pieces: 6, ItemLevel: 15},
+			{Pieces: 9, ItemLevel: 30},
+		},
+	},
+	{
+		Name: "Lucifer",
+		Pieces: [9]string{
+			Head:     "Helm of Lucifer",
+			Torso:    "Chestplate of Lucifer’s Flame",
+			Legs:     "Frozen Greaves of Lucifer",
+			Arms:     "Vambraces of Lucifer",
+			Gloves:   "Gauntlets of Lucifer",
+			Boots:    "Boots of Lucifer",
+			Necklace: "Pendant of Lucifer",
+			Ring:     "Ring of Lucifer",
+			Weapon:   "Lucifer’s Talon",
+		},
+		Bonuses: []SetBonus{
+			{Pieces: 3, ItemLevel: 5, ItemFind: 5},
+			{Pieces: 6, ItemLevel: 10, ItemFind: 10},
+			{Pieces: 9, ItemLevel: 20, ItemFind: 20},
+		},
+	},
+}
+
+// SetStatus is how much of a set a player is wearing.
+type SetStatus struct {
+	Set    *ItemSet
+	Pieces int
+	// Bonus is the best bonus reached, if any
+	Bonus *SetBonus
+}
+
+func (s SetStatus) ToString() string {
+	status := fmt.Sprintf("%s %d/%d", s.Set.Name, s.Pieces, len(s.Set.Pieces))
+	if s.Bonus != nil {
+		status += fmt.Sprintf(" (%s)", s.Bonus.ToString())
+	}
+	return status
+}
+
+// Sets lists the sets the player is wearing at least one piece of.
+func (p *Player) Sets() []SetStatus {
+	sets := make([]SetStatus, 0)
+	for i := range ItemSets {
+		set := &ItemSets[i]
+		status := SetStatus{Set: set}
+		for class, name := range set.Pieces {
+			if item := p.Inventory[class]; item != nil && item.Name == name {
+				status.Pieces++
+			}
+		}
+		if status.Pieces == 0 {
+			continue
+		}
+		for j := range set.Bonuses {
+			if status.Pieces >= set.Bonuses[j].Pieces {
+				status.Bonus = &set.Bonuses[j]
+			}
+		}
+		sets = append(sets, status)
+	}
+	return sets
+}
+
+// setBonus adds up the bonuses of every set the player is wearing.
+func (p *Player) setBonus() SetBonus {
+	total := SetBonus{}
+	for _, status := range p.Sets() {
+		if status.Bonus == nil {
+			continue
+		}
+		total.ItemLevel += status.Bonus.ItemLevel
+		total.ItemFind += status.Bonus.ItemFind
+	}
+	return total
+}
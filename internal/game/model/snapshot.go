@@ -0,0 +1,110 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// RecentEvents is how many of the latest events a snapshot holds
+const RecentEvents = 20
+
+// PlayerSnapshot is a copy of a player's public state at one moment.
+type PlayerSnapshot struct {
+	Name      string `json:"name"`
+	Class     string `json:"class"`
+	Level     int    `json:"level"`
+	ItemLevel int    `json:"itemLevel"`
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+}
+
+// Snapshot is the world as it stood at the end of a tick.
+// Nothing in it changes once it's published, so any number of readers can share it
+// without holding up the game, and everything they read from it agrees.
+type Snapshot struct {
+	Time     time.Time        `json:"time"`
+	Players  []PlayerSnapshot `json:"players"`
+	Monsters []PlayerSnapshot `json:"monsters"`
+	// Widths of each circle, for placing the players around it
+	Widths [WorldSize]int `json:"widths"`
+	// Events are the latest events, oldest first
+	Events []Event `json:"events"`
+
+	// Copies of everyone in the world, by name
+	players map[string]*Player
+	// Copies of the fights with the guardians, and of the open trades, oldest first
+	bosses   [WorldSize]*Boss
+	trades   []*Trade
+	worldMap *Map
+}
+
+// Player is a copy of the player as they were when the snapshot was taken, or nil if they weren't in the world.
+// Every reader of the snapshot shares it, so it mustn't be changed.
+func (s *Snapshot) Player(name string) *Player {
+	return s.players[name]
+}
+
+// Snapshot is the world as of the last Publish.
+func (w *World) Snapshot() *Snapshot {
+	if s := w.published.Load(); s != nil {
+		return s
+	}
+	return w.Publish()
+}
+
+// Publish takes a new snapshot of the world for everything that reads it. The game publishes after every tick.
+func (w *World) Publish() *Snapshot {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	s := &Snapshot{
+		Time:     w.clock().Now(),
+		Players:  make([]PlayerSnapshot, 0, len(w.Players)),
+		Monsters: make([]PlayerSnapshot, 0, len(w.Monsters)),
+		Widths:   w.grid().Widths(),
+		Events:   append([]Event{}, w.recent...),
+		players:  make(map[string]*Player, len(w.Players)),
+		trades:   make([]*Trade, 0, len(w.trades)),
+		worldMap: w.worldMap(),
+	}
+	for _, p := range w.Players {
+		s.Players = append(s.Players, snapshotPlayer(p))
+		s.players[p.Name] = p.copy()
+	}
+	for _, m := range w.Monsters {
+		s.Monsters = append(s.Monsters, snapshotPlayer(m))
+	}
+	for circle, boss := range w.Bosses {
+		if boss != nil {
+			s.bosses[circle] = boss.copy()
+		}
+	}
+	for _, trade := range w.trades {
+		s.trades = append(s.trades, trade.copy())
+	}
+	slices.SortFunc(s.trades, func(a, b *Trade) int {
+		return a.Time.Compare(b.Time)
+	})
+	w.published.Store(s)
+	return s
+}
+
+func snapshotPlayer(p *Player) PlayerSnapshot {
+	return PlayerSnapshot{
+		Name:      p.Name,
+		Class:     p.Class,
+		Level:     p.Stats.Level,
+		ItemLevel: p.ItemLevel(),
+		X:         p.Location.X,
+		Y:         p.Location.Y,
+	}
+}
+
+// remember keeps the event for the next snapshot.
+// Must be called with w.mut held.
+func (w *World) remember(e Event) {
+	if len(w.recent) == RecentEvents {
+		w.recent = append(w.recent[:0], w.recent[1:]...)
+	}
+	w.recent = append(w.recent, e)
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StashSize is how many replaced items a player keeps hold of.
+// Past that, the oldest one is thrown away.
+const StashSize = 5
+
+// stash keeps an item the player just took off, newest first,
+// and returns the item that fell out of the stash to make room, if any.
+func (p *Player) stash(item *Item) *Item {
+	if item == nil {
+		return nil
+	}
+	p.Stash = append([]*Item{item}, p.Stash...)
+	if len(p.Stash) <= StashSize {
+		return nil
+	}
+	discarded := p.Stash[StashSize]
+	p.Stash = p.Stash[:StashSize]
+	return discarded
+}
+
+// CompareStash describes each stashed item against what the player is wearing in its place.
+func (p *Player) CompareStash() []string {
+	lines := make([]string, 0, len(p.Stash))
+	for i, item := range p.Stash {
+		line := fmt.Sprintf("%d. %s (%s)", i+1, item.ToString(), item.Class)
+		if worn := p.Inventory[item.Class]; worn != nil {
+			line += fmt.Sprintf(", wearing a %s (%+d)", worn.ToString(), worn.ItemLevel-item.ItemLevel)
+		} else {
+			line += ", nothing worn there"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// CompareStash is Player.CompareStash for a player in the world when the snapshot was taken.
+func (s *Snapshot) CompareStash(name string) ([]string, error) {
+	player := s.Player(name)
+	if player == nil {
+		return nil, errors.New("You need to be in the world to look in your stash.")
+	}
+	return player.CompareStash(), nil
+}
+
+// Swap puts the player's nth stashed item (counting from 1) back on,
+// and stashes what they were wearing in its place.
+func (w *World) Swap(name string, n int) (worn, stashed *Item, err error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	player := w.player(name)
+	if player == nil {
+		return nil, nil, errors.New("You need to be in the world to swap items.")
+	}
+	if n < 1 || n > len(player.Stash) {
+		return nil, nil, fmt.Errorf("You have nothing at %d in your stash.", n)
+	}
+
+	worn = player.Stash[n-1]
+	stashed = player.Inventory[worn.Class]
+	if stashed != nil && stashed.Escrow {
+		return nil, nil, fmt.Errorf("Your %s is on offer in a trade.", stashed.Name)
+	}
+
+	player.Inventory[worn.Class] = worn
+	if stashed != nil {
+		player.Stash[n-1] = stashed
+	} else {
+		player.Stash = append(player.Stash[:n-1], player.Stash[n:]...)
+	}
+	return worn, stashed, nil
+}
@@ -1,79 +1,110 @@
 package model
 
 import (
+	"fmt"
 	"math"
+	"time"
 )
 
 type Stats struct {
-	Xp      uint64
-	Created string
-	Online  bool
-}
-
-func (s *Stats) IncrementXp() {
-	s.Xp += 1
-}
-
-func (s *Stats) DecrementXp() {
-	if s.Xp > 0 {
-		s.Xp -= 1
-	}
+	Level int
+	// TimeToLevel is how much longer the player has to idle to reach the next level
+	TimeToLevel time.Duration
+	Created     time.Time
+	Online      bool
+	// LastSeen is the last time the player was in the world
+	LastSeen time.Time
+	// LastCraft is when the player last used a forge
+	LastCraft time.Time
 }
 
 const (
-	// With these factors, it takes 229.4 days to reach level 100 @ 1xp per minute.
+	// With these factors, it takes 229.4 days to reach level 100
 	// if the player remains logged in 24/7...
 	C = 20
 	x = 2
 )
 
-/*
-Base xp formula:
-xpNeededToLevelUp=currentLevel^x + C
-
-Therefore, we can express current level as:
-xpNeededToLevelUp − C=currentLevel^x
-currentLevel=(xp − C)^(1/x)
+// Percentages of the time to level that are taken off, or added on.
+// Fights happen every tick for anyone with a neighbor, so they move it much less.
+const (
+	FightWinBonus    = 0.02
+	FightLossPenalty = 0.02
+	BlessingBonus    = 10.0
+	CursePenalty     = 10.0
+)
 
-To find the XP required to reach the next level:
-xpNeededToNextLevel=currentLevel^x + C
+/*
+Base leveling formula, in minutes:
+timeNeededToLevelUp=currentLevel^x + C
 
-There is no explicit closed form to calculate the level.
-This is due to the sum of series property of the xp formula.
+This is the old xp formula at 1xp per minute,
+so levels take as long to earn as they always did.
 */
 
-// Level calculates the player's current level based on their XP.
-func (s Stats) Level() int {
-	// Binary search to find the level based on XP
-	low, high := 0, int(s.Xp)
+// TimeForLevel is how long it takes to go from level to the next one.
+func TimeForLevel(level int) time.Duration {
+	return time.Duration(math.Pow(float64(level), float64(x))+C) * time.Minute
+}
 
-	for low < high {
-		mid := (low + high + 1) / 2
-		if s.totalXpForLevel(mid) <= s.Xp {
-			low = mid
-		} else {
-			high = mid - 1
-		}
-	}
-	return low
+// NewStats is where every sinner starts, at level 0.
+func NewStats() *Stats {
+	return &Stats{TimeToLevel: TimeForLevel(0)}
 }
 
-func (s Stats) totalXpForLevel(level int) uint64 {
-	if level == 0 {
-		return 0
+// Idle counts d towards the next level, and returns how many levels were gained.
+func (s *Stats) Idle(d time.Duration) int {
+	s.TimeToLevel -= d
+	gained := 0
+	for s.TimeToLevel <= 0 {
+		s.Level++
+		gained++
+		s.TimeToLevel += TimeForLevel(s.Level)
 	}
-	total := uint64(0)
+	return gained
+}
+
+// Bonus takes percent of the remaining time to level off.
+func (s *Stats) Bonus(percent float64) time.Duration {
+	d := time.Duration(float64(s.TimeToLevel) * percent / 100)
+	s.TimeToLevel -= d
+	return d
+}
 
-	// Calculate cumulative XP required to reach the specified level
-	for i := 0; i < level; i++ {
-		total += uint64(math.Pow(float64(i), float64(x))) + C
+// Penalty adds percent of the remaining time to level on.
+func (s *Stats) Penalty(percent float64) time.Duration {
+	d := time.Duration(float64(s.TimeToLevel) * percent / 100)
+	s.TimeToLevel += d
+	return d
+}
+
+// FromXp converts the old xp count, earned at 1xp per minute, into a level
+// and the time left until the next one.
+func FromXp(xp uint64) Stats {
+	s := Stats{}
+	remaining := time.Duration(xp) * time.Minute
+	for remaining >= TimeForLevel(s.Level) {
+		remaining -= TimeForLevel(s.Level)
+		s.Level++
 	}
-	return total
+	s.TimeToLevel = TimeForLevel(s.Level) - remaining
+	return s
 }
 
-func (s Stats) UntilNextLevel() uint {
-	level := s.Level()
-	nextXp := uint64(math.Pow(float64(level), float64(x))) + C
-	currentTotalXp := s.totalXpForLevel(level)
-	return uint(nextXp - (s.Xp - currentTotalXp))
+// FormatDuration renders a duration IdleRPG style, e.g. "2 days, 04:13:22".
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	unit := "days"
+	if days == 1 {
+		unit = "day"
+	}
+	return fmt.Sprintf("%d %s, %02d:%02d:%02d", days, unit, hours, minutes, seconds)
 }
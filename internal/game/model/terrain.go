@@ -0,0 +1,241 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Terrain is what a cell of the grid is like underfoot, and how it bends the luck of whoever stands there.
+type Terrain struct {
+	Name   string
+	Symbol rune
+
+	// Wander scales the chance of taking a step off it
+	Wander float64
+	// ItemFind scales the chance of finding an item on it
+	ItemFind float64
+	// FightRoll scales the rolls of anyone fighting from it
+	FightRoll float64
+}
+
+var (
+	Ground = &Terrain{Name: "ground", Symbol: '.', Wander: 1.0, ItemFind: 1.0, FightRoll: 1.0}
+	Styx   = &Terrain{Name: "the river Styx", Symbol: '~', Wander: 0.5, ItemFind: 0.8, FightRoll: 1.1}
+	Sands  = &Terrain{Name: "burning sands", Symbol: ':', Wander: 1.0, ItemFind: 1.2, FightRoll: 0.85}
+	Ice    = &Terrain{Name: "the ice of Cocytus", Symbol: '*', Wander: 0.25, ItemFind: 1.0, FightRoll: 0.9}
+	Gates  = &Terrain{Name: "the gates of Dis", Symbol: '#', Wander: 1.0, ItemFind: 0.9, FightRoll: 1.25}
+)
+
+var Terrains = []*Terrain{Ground, Styx, Sands, Ice, Gates}
+
+// PointKind is a special place on the map, that does something to whoever steps on it.
+type PointKind int
+
+const (
+	NoPoint PointKind = iota
+	// Shrine takes time off the next level of whoever steps on it, once every ShrineCooldown
+	Shrine
+	// Portal takes whoever steps on it to the other portal with the same number
+	Portal
+)
+
+const (
+	ShrineBonus    = 5.0
+	ShrineCooldown = 6 * time.Hour
+	// PortalCooldown keeps players from bouncing back and forth through a pair of portals
+	PortalCooldown = time.Hour
+)
+
+var landmarkCooldowns = map[PointKind]time.Duration{
+	Shrine: ShrineCooldown,
+	Portal: PortalCooldown,
+}
+
+// Tile is a cell of the map.
+type Tile struct {
+	Terrain *Terrain
+	Point   PointKind
+	// Portal is the number of the portal on the tile, 1-9
+	Portal int
+}
+
+// Map lays out the terrain and the points of interest of each circle.
+// Circles can be wider than their row of the map, and the cells past it are plain ground.
+type Map struct {
+	rows    [WorldSize][]Tile
+	portals map[Coordinates]Coordinates
+}
+
+// LoadMap reads a map: a line of tiles for each circle, from Limbo down, and ; for comments.
+// See content/map.txt for the tiles.
+func LoadMap(r io.Reader) (*Map, error) {
+	m := &Map{portals: make(map[Coordinates]Coordinates)}
+	ends := make(map[int][]Coordinates)
+
+	circle := 0
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, ";") {
+			continue
+		}
+		if circle == WorldSize {
+			return nil, fmt.Errorf("Line %d: the map has more than %d circles.", line, WorldSize)
+		}
+
+		row := make([]Tile, 0, len(text))
+		for x, symbol := range []rune(text) {
+			tile, err := parseTile(symbol)
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %w", line, err)
+			}
+			if tile.Point == Portal {
+				ends[tile.Portal] = append(ends[tile.Portal], Coordinates{X: x, Y: circle})
+			}
+			row = append(row, tile)
+		}
+		m.rows[circle] = row
+		circle++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if circle < WorldSize {
+		return nil, fmt.Errorf("The map has %d circles, it needs %d.", circle, WorldSize)
+	}
+
+	for n, coords := range ends {
+		if len(coords) != 2 {
+			return nil, fmt.Errorf("Portal %d appears %d times, it needs to be in exactly 2 places.", n, len(coords))
+		}
+		m.portals[coords[0]] = coords[1]
+		m.portals[coords[1]] = coords[0]
+	}
+	return m, nil
+}
+
+func parseTile(symbol rune) (Tile, error) {
+	for _, t := range Terrains {
+		if t.Symbol == symbol {
+			return Tile{Terrain: t}, nil
+		}
+	}
+	switch {
+	case symbol == '+':
+		return Tile{Terrain: Ground, Point: Shrine}, nil
+	case symbol >= '1' && symbol <= '9':
+		return Tile{Terrain: Ground, Point: Portal, Portal: int(symbol - '0')}, nil
+	}
+	return Tile{}, fmt.Errorf("Unknown tile %q.", symbol)
+}
+
+// Tile is what's at c. Anywhere off the map is plain ground.
+func (m *Map) Tile(c Coordinates) Tile {
+	if c.Y < 0 || c.Y >= WorldSize || c.X < 0 || c.X >= len(m.rows[c.Y]) {
+		return Tile{Terrain: Ground}
+	}
+	return m.rows[c.Y][c.X]
+}
+
+// Widths are how wide the map draws each circle.
+func (m *Map) Widths() [WorldSize]int {
+	widths := [WorldSize]int{}
+	for circle, row := range m.rows {
+		widths[circle] = len(row)
+	}
+	return widths
+}
+
+func (t Tile) Symbol() rune {
+	switch t.Point {
+	case Shrine:
+		return '+'
+	case Portal:
+		return rune('0' + t.Portal)
+	}
+	return t.Terrain.Symbol
+}
+
+// worldMap must be called with w.mut held.
+func (w *World) worldMap() *Map {
+	if w.Map == nil {
+		return content.Map
+	}
+	return w.Map
+}
+
+// terrain is what the player is standing on.
+// Must be called with w.mut held.
+func (w *World) terrain(player *Player) *Terrain {
+	return w.worldMap().Tile(*player.Location).Terrain
+}
+
+// landmarkVisit is a player's last use of a kind of point of interest.
+type landmarkVisit struct {
+	player string
+	point  PointKind
+}
+
+// visit sets off the point of interest the player just stepped on, if there is one
+// and they haven't used one like it too recently.
+// Must be called with w.mut held.
+func (w *World) visit(player *Player) {
+	at := *player.Location
+	point := w.worldMap().Tile(at).Point
+	if point == NoPoint {
+		return
+	}
+	now := w.clock().Now()
+	visit := landmarkVisit{player: player.Name, point: point}
+	if last, ok := w.landmarks[visit]; ok && now.Before(last.Add(landmarkCooldowns[point])) {
+		return
+	}
+
+	switch point {
+	case Shrine:
+		w.visited(visit, now)
+		player.Stats.Bonus(ShrineBonus)
+		w.emit(Event{
+			Kind:   LandmarkEvent,
+			Player: player.Name,
+			Message: fmt.Sprintf("%s knelt at a shrine in %s, and their next level draws nearer. Next level in %s.",
+				player.Name, CircleNames[at.Y], FormatDuration(player.Stats.TimeToLevel)),
+		})
+
+	case Portal:
+		to, ok := w.worldMap().portals[at]
+		if !ok {
+			return
+		}
+		// Out the other side, or beside it if someone's in the way
+		if w.grid().At(to) != nil {
+			beside := w.getEmptyNeighborCoords(&to)
+			if len(beside) == 0 {
+				return
+			}
+			to = beside[w.rng().IntN(len(beside))]
+		}
+		if !w.grid().Contains(to) {
+			return
+		}
+		w.grid().move(player, to)
+		w.visited(visit, now)
+		w.emit(Event{
+			Kind:   LandmarkEvent,
+			Player: player.Name,
+			Message: fmt.Sprintf("%s stepped through a portal in %s, and came out in %s.",
+				player.Name, CircleNames[at.Y], CircleNames[to.Y]),
+		})
+	}
+}
+
+// visited must be called with w.mut held.
+func (w *World) visited(visit landmarkVisit, now time.Time) {
+	if w.landmarks == nil {
+		w.landmarks = make(map[landmarkVisit]time.Time)
+	}
+	w.landmarks[visit] = now
+}
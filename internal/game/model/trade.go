@@ -0,0 +1,282 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trade is an offer from one player to another to hand over an item,
+// ask for one of theirs, or both.
+// The items on offer are held in escrow until the trade is accepted or cancelled.
+type Trade struct {
+	Id   string
+	From string
+	To   string
+	// Give is what From hands over, Take is what they want from To. Either may be nil, not both.
+	Give *Item
+	Take *Item
+	Time time.Time
+}
+
+func (t *Trade) ToString() string {
+	give, take := "nothing", "nothing"
+	if t.Give != nil {
+		give = "their " + t.Give.ToString()
+	}
+	if t.Take != nil {
+		take = "your " + t.Take.ToString()
+	}
+	return fmt.Sprintf("%s offers %s for %s.", t.From, give, take)
+}
+
+// tradeKey is the same whichever way round the two players are.
+// Each pair of players can only have one trade open at a time.
+func tradeKey(a, b string) [2]string {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+// player must be called with w.mut held.
+func (w *World) player(name string) *Player {
+	return w.byName[name]
+}
+
+// Offer opens a trade from one player to another, replacing any open trade between them.
+// A nil slot means nothing is given, or taken.
+func (w *World) Offer(from, to string, give, take *ItemClass) (*Trade, error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	return w.offer(from, to, give, take)
+}
+
+// Counter answers an offer with different terms, which the other player can then accept.
+func (w *World) Counter(from, to string, give, take *ItemClass) (*Trade, error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	open, ok := w.trades[tradeKey(from, to)]
+	if !ok || open.To != from {
+		return nil, fmt.Errorf("%s hasn't offered you anything.", to)
+	}
+	return w.offer(from, to, give, take)
+}
+
+// offer must be called with w.mut held.
+func (w *World) offer(from, to string, give, take *ItemClass) (*Trade, error) {
+	if from == to {
+		return nil, errors.New("You can't trade with yourself.")
+	}
+	if give == nil && take == nil {
+		return nil, errors.New("A trade needs at least one item.")
+	}
+	fromPlayer, toPlayer := w.player(from), w.player(to)
+	if fromPlayer == nil {
+		return nil, errors.New("You need to be in the world to trade.")
+	}
+	if toPlayer == nil {
+		return nil, fmt.Errorf("%s isn't in the world.", to)
+	}
+
+	trade := &Trade{
+		Id:   uuid.New().String(),
+		From: from,
+		To:   to,
+		Time: w.clock().Now(),
+	}
+	if give != nil {
+		trade.Give = fromPlayer.Inventory[*give]
+		if trade.Give == nil {
+			return nil, fmt.Errorf("You have nothing in your %s slot.", *give)
+		}
+	}
+	if take != nil {
+		trade.Take = toPlayer.Inventory[*take]
+		if trade.Take == nil {
+			return nil, fmt.Errorf("%s has nothing in their %s slot.", to, *take)
+		}
+	}
+	err := trade.check(fromPlayer, toPlayer)
+	if err != nil {
+		return nil, err
+	}
+	open := w.trades[tradeKey(from, to)]
+	for _, item := range []*Item{trade.Give, trade.Take} {
+		if item != nil && item.Escrow && !open.holds(item) {
+			return nil, fmt.Errorf("The %s is already on offer in another trade.", item.Name)
+		}
+	}
+
+	w.closeTrade(from, to)
+	if w.trades == nil {
+		w.trades = make(map[[2]string]*Trade)
+	}
+	w.trades[tradeKey(from, to)] = trade
+	trade.escrow(true)
+	return trade, nil
+}
+
+// check makes sure the trade can still go ahead, and that nobody would lose an item by it.
+func (t *Trade) check(from, to *Player) error {
+	if from == nil || to == nil {
+		return errors.New("Both players need to be in the world to trade.")
+	}
+	if from.Location.Y != to.Location.Y {
+		return fmt.Errorf("%s and %s need to be in the same circle to trade.", from.Name, to.Name)
+	}
+	if t.Give != nil && from.Inventory[t.Give.Class] != t.Give {
+		return fmt.Errorf("%s no longer has the %s.", from.Name, t.Give.Name)
+	}
+	if t.Take != nil && to.Inventory[t.Take.Class] != t.Take {
+		return fmt.Errorf("%s no longer has the %s.", to.Name, t.Take.Name)
+	}
+
+	// What you get goes into its slot, which has to be empty, or be what you're giving away
+	if t.Give != nil {
+		if held := to.Inventory[t.Give.Class]; held != nil && held != t.Take {
+			return fmt.Errorf("%s would have to throw away their %s to take the %s.", to.Name, held.Name, t.Give.Name)
+		}
+	}
+	if t.Take != nil {
+		if held := from.Inventory[t.Take.Class]; held != nil && held != t.Give {
+			return fmt.Errorf("%s would have to throw away their %s to take the %s.", from.Name, held.Name, t.Take.Name)
+		}
+	}
+	return nil
+}
+
+func (t *Trade) holds(item *Item) bool {
+	return t != nil && (t.Give == item || t.Take == item)
+}
+
+func (t *Trade) escrow(held bool) {
+	if t.Give != nil {
+		t.Give.Escrow = held
+	}
+	if t.Take != nil {
+		t.Take.Escrow = held
+	}
+}
+
+// Accept carries out the trade the other player offered.
+// commit stores the trade, and the world only changes if it succeeds.
+func (w *World) Accept(name, from string, commit func(*Trade) error) (*Trade, error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	trade, ok := w.trades[tradeKey(name, from)]
+	if !ok || trade.To != name {
+		return nil, fmt.Errorf("%s hasn't offered you anything.", from)
+	}
+	fromPlayer, toPlayer := w.player(trade.From), w.player(trade.To)
+	err := trade.check(fromPlayer, toPlayer)
+	if err != nil {
+		w.closeTrade(name, from)
+		return nil, err
+	}
+
+	err = commit(trade)
+	if err != nil {
+		return nil, err
+	}
+
+	w.closeTrade(name, from)
+	if trade.Give != nil {
+		fromPlayer.Inventory[trade.Give.Class] = nil
+	}
+	if trade.Take != nil {
+		toPlayer.Inventory[trade.Take.Class] = nil
+	}
+	if trade.Give != nil {
+		trade.Give.Player = toPlayer.Name
+		toPlayer.Inventory[trade.Give.Class] = trade.Give
+	}
+	if trade.Take != nil {
+		trade.Take.Player = fromPlayer.Name
+		fromPlayer.Inventory[trade.Take.Class] = trade.Take
+	}
+
+	w.emit(Event{
+		Kind:     TradeEvent,
+		Player:   trade.From,
+		Opponent: trade.To,
+		Message:  fmt.Sprintf("%s and %s struck a deal: %s", trade.From, trade.To, trade.Summary()),
+	})
+	return trade, nil
+}
+
+// Summary says where each item went.
+func (t *Trade) Summary() string {
+	parts := make([]string, 0, 2)
+	if t.Give != nil {
+		parts = append(parts, fmt.Sprintf("the %s went to %s", t.Give.Name, t.To))
+	}
+	if t.Take != nil {
+		parts = append(parts, fmt.Sprintf("the %s went to %s", t.Take.Name, t.From))
+	}
+	return strings.Join(parts, " and ") + "."
+}
+
+// Cancel withdraws, or turns down, the open trade between two players.
+func (w *World) Cancel(name, other string) (*Trade, error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	trade := w.closeTrade(name, other)
+	if trade == nil {
+		return nil, fmt.Errorf("You have no open trade with %s.", other)
+	}
+	return trade, nil
+}
+
+// Trades lists the open trades a player was part of when the snapshot was taken, oldest first.
+func (s *Snapshot) Trades(name string) []*Trade {
+	trades := make([]*Trade, 0)
+	for _, trade := range s.trades {
+		if trade.From == name || trade.To == name {
+			trades = append(trades, trade)
+		}
+	}
+	return trades
+}
+
+func (t *Trade) copy() *Trade {
+	c := *t
+	if t.Give != nil {
+		give := *t.Give
+		c.Give = &give
+	}
+	if t.Take != nil {
+		take := *t.Take
+		c.Take = &take
+	}
+	return &c
+}
+
+// closeTrade must be called with w.mut held.
+func (w *World) closeTrade(a, b string) *Trade {
+	key := tradeKey(a, b)
+	trade, ok := w.trades[key]
+	if !ok {
+		return nil
+	}
+	delete(w.trades, key)
+	trade.escrow(false)
+	return trade
+}
+
+// closeTrades cancels everything the player had open, e.g. as they log out.
+// Must be called with w.mut held.
+func (w *World) closeTrades(name string) {
+	for key := range w.trades {
+		if key[0] == name || key[1] == name {
+			w.closeTrade(key[0], key[1])
+		}
+	}
+}
@@ -5,14 +5,27 @@ import (
 	"fmt"
 	"log"
 	"math/rand/v2"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
 )
 
-// Probably only the 3 of us playing, so...
-// I guess each circle is only 1 array
+// WorldSize is how many circles there are, each a row of the Grid.
 const WorldSize int = 9
 
+// terrainArtWidth is as many cells of a circle as ToString draws
+const terrainArtWidth = 60
+
+// CircleNames names each row of the world, from the top down.
+var CircleNames = [WorldSize]string{
+	"Limbo", "Lust", "Gluttony", "Greed", "Wrath",
+	"Heresy", "Violence", "Fraud", "Treachery",
+}
+
 type Coordinates struct {
 	X int
 	Y int
@@ -23,42 +36,140 @@ var Down Coordinates = Coordinates{X: 0, Y: 1}
 var Left Coordinates = Coordinates{X: -1, Y: 0}
 var Right Coordinates = Coordinates{X: 1, Y: 0}
 
+// World is everyone in the inferno, and everything going on there.
+//
+// One lock guards all of it. Each exported method holds the lock for the whole of its work,
+// so a tick's steps, logins and the read APIs never see each other half done,
+// and what they hand back is a copy or a description, never something a tick could change under the caller.
+// A player handed to Login belongs to the world until Logout returns,
+// and is only read or written through the world's methods in between.
+// Readers that only look can use the published Snapshot instead, without taking the lock at all.
+// The exported fields are for setting up the world before the game starts, and for reading it after it stops.
 type World struct {
 	Players []*Player
-	Grid    [WorldSize][WorldSize]*Player
+	// Monsters roam the grid alongside the players, but are never saved
+	Monsters []*Player
+	// Grid is where everyone stands. When nil, it is built on first use, with each circle as wide as the map draws it.
+	Grid *Grid
+	// Bosses are the fights with each circle's guardian, nil until the guardian first rises
+	Bosses [WorldSize]*Boss
+	// Map is the terrain and the points of interest. When nil, the content pack's map is used.
+	Map *Map
+
+	// Rand drives every roll in the game. Seed it to replay a game exactly.
+	// When nil, a randomly seeded source is used.
+	Rand *rand.Rand
+	// Clock stamps the events. When nil, the wall clock is used.
+	Clock clock.Clock
+	// CatchUp, when set, gives players some progress for their time offline as they log in
+	CatchUp *CatchUp
+	// History, when set, keeps every event until SaveEvents takes it, so none are lost to a slow subscriber
+	History bool
 
 	mut sync.Mutex
+
+	// Players by name, for finding them without a scan
+	byName map[string]*Player
+
+	// Open trades, by the pair of players making them
+	trades map[[2]string]*Trade
+
+	// When each player last used each kind of point of interest
+	landmarks map[landmarkVisit]time.Time
+
+	// The latest events, for the next snapshot
+	recent []Event
+	// The events since the last SaveEvents, when keeping the History
+	unsaved []Event
+	// The last snapshot published
+	published atomic.Pointer[Snapshot]
+
+	subscribers []chan Event
+	subMut      sync.Mutex
+}
+
+// rng must be called with w.mut held.
+func (w *World) rng() *rand.Rand {
+	if w.Rand == nil {
+		w.Rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return w.Rand
 }
 
-func (w *World) Login(player *Player) (*Player, error) {
+// grid must be called with w.mut held.
+func (w *World) grid() *Grid {
+	if w.Grid == nil {
+		w.Grid = NewGrid(w.worldMap().Widths())
+	}
+	return w.Grid
+}
+
+func (w *World) clock() clock.Clock {
+	if w.Clock == nil {
+		return clock.Real{}
+	}
+	return w.Clock
+}
+
+// Login places the player in the world, unless someone by their name is already in it.
+// With catch up on, it also returns what they got up to while they were away.
+func (w *World) Login(player *Player) (*Player, *Away, error) {
 	w.mut.Lock()
 	defer w.mut.Unlock()
 
-	if w.Grid[player.Location.Y][player.Location.X] == nil {
-		w.Grid[player.Location.Y][player.Location.X] = player
-		w.Players = append(w.Players, player)
-		return player, nil
+	if w.player(player.Name) != nil {
+		return nil, nil, fmt.Errorf("%s is already in the world.", player.Name)
+	}
+	if !w.place(player) {
+		return nil, nil, errors.New("Unable to place player in world.")
 	}
 
-	for i := 0; i < WorldSize; i++ {
-		for j := 0; j < WorldSize; j++ {
-			if w.Grid[i][j] == nil {
-				w.Grid[i][j] = player
-				player.Location.X = j
-				player.Location.Y = i
-				w.Players = append(w.Players, player)
-				return player, nil
-			}
+	away := w.catchUp(player)
+	player.Stats.LastSeen = w.clock().Now()
+	return player, away, nil
+}
+
+// place stands the player where they last were, or as near as there's room for,
+// in the same circle. Must be called with w.mut held.
+func (w *World) place(player *Player) bool {
+	grid := w.grid()
+	at := Coordinates{
+		X: max(player.Location.X, 0),
+		Y: min(max(player.Location.Y, 0), WorldSize-1),
+	}
+	if at.X >= grid.Width(at.Y) {
+		at.X = grid.Width(at.Y) - 1
+	}
+
+	// Monsters make way for sinners
+	if occupant := grid.At(at); occupant != nil && occupant.Monster {
+		w.banish(occupant)
+	}
+	if grid.At(at) != nil {
+		var ok bool
+		at, ok = grid.empty(at.Y)
+		if !ok {
+			return false
 		}
 	}
 
-	return nil, errors.New("Unable to place player in world.")
+	grid.put(at, player)
+	w.Players = append(w.Players, player)
+	if w.byName == nil {
+		w.byName = make(map[string]*Player)
+	}
+	w.byName[player.Name] = player
+	return true
 }
 
+// Logout takes the player out of the world. Logging out someone who isn't in it does nothing.
 func (w *World) Logout(player *Player) {
 	w.mut.Lock()
 	defer w.mut.Unlock()
 
+	if w.player(player.Name) != player {
+		return
+	}
 	newPlayers := make([]*Player, 0, len(w.Players)-1)
 	for _, p := range w.Players {
 		if p.Name != player.Name {
@@ -66,26 +177,82 @@ func (w *World) Logout(player *Player) {
 		}
 	}
 	w.Players = newPlayers
-	w.Grid[player.Location.Y][player.Location.X] = nil
+	delete(w.byName, player.Name)
+	w.grid().clear(player)
+	player.Stats.LastSeen = w.clock().Now()
+	w.closeTrades(player.Name)
 }
 
-func (w *World) Wander() {
+// Save hands every player to save, with the world held still so that
+// nothing, a trade in particular, changes while they're being saved.
+func (w *World) Save(save func(*Player)) {
 	w.mut.Lock()
 	defer w.mut.Unlock()
 
 	for _, player := range w.Players {
-		player.Stats.IncrementXp()
+		save(player)
+	}
+}
+
+// SavePlayer hands the player to save, with the world held still, and reports whether they're in it.
+func (w *World) SavePlayer(name string, save func(*Player)) bool {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	player := w.player(name)
+	if player == nil {
+		return false
+	}
+	save(player)
+	return true
+}
+
+// SaveBosses hands the fight with each guardian that has risen to save, with the world held still.
+func (w *World) SaveBosses(save func(*Boss)) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	for _, boss := range w.Bosses {
+		if boss != nil {
+			save(boss)
+		}
+	}
+}
+
+// Wander moves everyone a step, and counts the time elapsed towards their next level.
+func (w *World) Wander(elapsed time.Duration) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	now := w.clock().Now()
+	for _, player := range w.Players {
+		player.Stats.LastSeen = now
+		w.levelUp(player, player.Stats.Idle(elapsed))
+		if w.rng().Float64() < player.class().Wander*w.terrain(player).Wander {
+			w.step(player)
+		}
+		w.checkAchievements(player)
+	}
+}
+
+// step moves the player towards their goal, or with none, to a random empty neighboring cell,
+// and sets off whatever is there. Must be called with w.mut held.
+func (w *World) step(player *Player) {
+	destCoords, ok := w.seek(player)
+	if !ok {
 		emptyNeighborCoords := w.getEmptyNeighborCoords(player.Location)
 		emptyNeighborCoordsLen := len(emptyNeighborCoords)
 		if emptyNeighborCoordsLen == 0 {
-			continue
+			return
 		}
-		destCoords := emptyNeighborCoords[rand.IntN(emptyNeighborCoordsLen)]
-		w.Grid[player.Location.Y][player.Location.X] = nil
-		w.Grid[destCoords.Y][destCoords.X] = player
-		player.Location.X = destCoords.X
-		player.Location.Y = destCoords.Y
+		destCoords = emptyNeighborCoords[w.rng().IntN(emptyNeighborCoordsLen)]
 	}
+	if destCoords == *player.Location {
+		// Already where they want to be
+		return
+	}
+	w.grid().move(player, destCoords)
+	w.visit(player)
 }
 
 func (w *World) Scavenge() {
@@ -93,12 +260,33 @@ func (w *World) Scavenge() {
 	defer w.mut.Unlock()
 
 	for _, player := range w.Players {
-		player.FindItem()
+		item, replaced := player.FindItem(w.rng(), w.terrain(player).ItemFind)
+		if item == nil {
+			continue
+		}
+		e := Event{
+			Kind:    ItemEvent,
+			Player:  player.Name,
+			Level:   item.ItemLevel,
+			Item:    item.Name,
+			Message: fmt.Sprintf("%s found and equipped a %s", player.Name, item.ToString()),
+		}
+		if replaced != nil {
+			e.Replaced = replaced.Name
+			e.Message += fmt.Sprintf(", stashing their %s", replaced.ToString())
+		}
+		if discarded := player.stash(replaced); discarded != nil {
+			e.Message += fmt.Sprintf(" and discarding their %s", discarded.ToString())
+		}
+		w.emit(e)
 	}
 }
 
+// Arena has everyone equipped fight one of their neighbors, if they have one.
+// It holds the world still throughout, as every fight changes the stats of two players.
 func (w *World) Arena() {
 	w.mut.Lock()
+	defer w.mut.Unlock()
 
 	combatants := make([]*Player, 0)
 	for _, player := range w.Players {
@@ -106,59 +294,83 @@ func (w *World) Arena() {
 			combatants = append(combatants, player)
 		}
 	}
-	w.mut.Unlock()
 
 	if len(combatants) == 0 {
-		fmt.Println("No players available for combat.")
+		log.Println("No players available for combat.")
 		return
 	}
 
-	alreadyFought := make(map[string]bool)
+	alreadyFought := make(map[*Player]bool)
 
 	for _, player := range combatants {
-		if alreadyFought[player.Name] {
+		if alreadyFought[player] {
 			continue
 		}
 
-		w.mut.Lock()
 		neighborCoords := w.getOccupiedNeighborCoords(player.Location)
-		w.mut.Unlock()
-
 		if len(neighborCoords) == 0 {
 			continue
 		}
 
-		opponentCoords := neighborCoords[rand.IntN(len(neighborCoords))]
-		opponent := w.Grid[opponentCoords.Y][opponentCoords.X]
+		opponentCoords := neighborCoords[w.rng().IntN(len(neighborCoords))]
+		opponent := w.grid().At(opponentCoords)
 
-		if alreadyFought[opponent.Name] {
+		// Only the equipped can fight back
+		if alreadyFought[opponent] || opponent.ItemLevel() == 0 {
 			continue
 		}
 
-		fightResult := w.fight(player, opponent)
-		log.Println(fightResult)
+		e := w.fight(player, opponent)
+		if opponent.Monster && e.Winner == player.Name {
+			w.slay(player, opponent, &e)
+		}
+		w.emit(e)
 
-		alreadyFought[player.Name] = true
-		alreadyFought[opponent.Name] = true
+		alreadyFought[player] = true
+		alreadyFought[opponent] = true
 	}
 }
 
-func (w *World) fight(player, opponent *Player) string {
-	playerRoll := rand.IntN(player.ItemLevel())
-	opponentRoll := rand.IntN(opponent.ItemLevel())
+func (w *World) fight(player, opponent *Player) Event {
+	playerRoll := w.roll(player)
+	opponentRoll := w.roll(opponent)
 
 	if playerRoll > opponentRoll {
-		player.Stats.IncrementXp()
-		opponent.Stats.DecrementXp()
-		return fmt.Sprintf("%s (%d) challenged %s (%d) and won!",
-			player.Name, playerRoll, opponent.Name, opponentRoll)
+		player.Stats.Bonus(FightWinBonus)
+		opponent.Stats.Penalty(FightLossPenalty)
+		return Event{
+			Kind:         FightEvent,
+			Player:       player.Name,
+			Opponent:     opponent.Name,
+			Winner:       player.Name,
+			PlayerRoll:   playerRoll,
+			OpponentRoll: opponentRoll,
+			Level:        playerRoll,
+			Message: fmt.Sprintf("%s (%d) challenged %s (%d) and won!",
+				player.Name, playerRoll, opponent.Name, opponentRoll),
+		}
 	} else {
-		opponent.Stats.IncrementXp()
-		player.Stats.DecrementXp()
-		return fmt.Sprintf("%s (%d) challenged %s (%d) and lost!",
-			player.Name, playerRoll, opponent.Name, opponentRoll)
+		opponent.Stats.Bonus(FightWinBonus)
+		player.Stats.Penalty(FightLossPenalty)
+		return Event{
+			Kind:         FightEvent,
+			Player:       player.Name,
+			Opponent:     opponent.Name,
+			Winner:       opponent.Name,
+			PlayerRoll:   playerRoll,
+			OpponentRoll: opponentRoll,
+			Level:        opponentRoll,
+			Message: fmt.Sprintf("%s (%d) challenged %s (%d) and lost!",
+				player.Name, playerRoll, opponent.Name, opponentRoll),
+		}
 	}
 }
+
+// roll is the player's attack in the arena, out of their item level.
+func (w *World) roll(player *Player) int {
+	return int(float64(w.rng().IntN(player.ItemLevel())) * player.class().FightRoll * w.terrain(player).FightRoll)
+}
+
 func (w *World) Revelation() {
 	w.mut.Lock()
 	defer w.mut.Unlock()
@@ -168,33 +380,53 @@ func (w *World) Revelation() {
 	}
 
 	// 2% chance of Revelation occurring
-	if rand.IntN(100) < 2 {
-		chosenPlayer := w.Players[rand.IntN(len(w.Players))]
+	if w.rng().IntN(100) < 2 {
+		chosenPlayer := w.Players[w.rng().IntN(len(w.Players))]
 
-		message := w.getRevelation(chosenPlayer)
-		log.Println(message)
+		w.emit(w.getRevelation(chosenPlayer))
 	}
 }
 
-func (w *World) getRevelation(player *Player) string {
-	isBlessing := rand.IntN(2) == 0
+func (w *World) getRevelation(player *Player) Event {
+	isBlessing := w.rng().Float64() < player.class().Blessing
 	layer := player.Location.Y
 	revelation := ""
 
 	if isBlessing {
-		revelation = blessings[layer][rand.IntN(len(blessings[layer]))]
-		player.Stats.IncrementXp()
+		revelation = getBlessing(w.rng(), layer)
+		player.Stats.Bonus(BlessingBonus)
 	} else {
-		revelation = curses[layer][rand.IntN(len(curses[layer]))]
-		player.Stats.DecrementXp()
+		revelation = getCurse(w.rng(), layer)
+		player.Stats.Penalty(CursePenalty)
+	}
+	return Event{
+		Kind:    RevelationEvent,
+		Player:  player.Name,
+		Curse:   !isBlessing,
+		Message: fmt.Sprintf("The heavens tremble, and Hell quakes as %s beholds a divine revelation: %s", player.Name, revelation),
 	}
-	return fmt.Sprintf("The heavens tremble, and Hell quakes as %s beholds a divine revelation: %s", player.Name, revelation)
 }
 
+// levelUp announces the levels the player just gained, if any.
+func (w *World) levelUp(player *Player, gained int) {
+	if gained == 0 {
+		return
+	}
+	w.emit(Event{
+		Kind:   LevelUpEvent,
+		Player: player.Name,
+		Level:  player.Stats.Level,
+		Message: fmt.Sprintf("%s has attained level %d! Next level in %s.",
+			player.Name, player.Stats.Level, FormatDuration(player.Stats.TimeToLevel)),
+	})
+}
+
+// ToString draws the world as of its last snapshot.
 func (w *World) ToString() string {
-	w.mut.Lock()
-	defer w.mut.Unlock()
+	return w.Snapshot().ToString()
+}
 
+func (s *Snapshot) ToString() string {
 	infernoArt := []string{
 		"       __________________________________  ",
 		"      |       Circle 1: Limbo          |  ",
@@ -272,9 +504,11 @@ func (w *World) ToString() string {
 		"                                            ",
 		"____________________________________________",
 	}
-	// Circle bounds for random placement
+	// Circle bounds for placement
 	xMin, xMax := 8, 36
 	yMin, yMax := 4, 7
+	rows := yMax - yMin + 1
+	spots := (xMax - xMin + 1) * rows
 
 	// Track taken coordinates to avoid player overlap
 	occupiedCoords := map[Coordinates]bool{}
@@ -282,22 +516,33 @@ func (w *World) ToString() string {
 	// Player coordinates mapping
 	playerCoords := map[string]Coordinates{}
 
-	// Randomly assign coordinates within the circle bounds
-	for _, player := range w.Players {
-		var coord Coordinates
-		for {
-			coord.X = rand.IntN(xMax-xMin+1) + xMin
-			coord.Y = rand.IntN(yMax-yMin+1) + (player.Location.Y * 8) + yMin
+	// Place each sinner as far across the circle bounds as they are around their circle, going down
+	// the rows before across, or at the next free spot after it. Going by name keeps the same world
+	// drawn the same way. A crowded circle has more sinners than room to draw them, so give up on a few.
+	players := slices.Clone(s.Players)
+	slices.SortFunc(players, func(a, b PlayerSnapshot) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, player := range players {
+		start := player.X * spots / max(s.Widths[player.Y], 1)
+		for i := range 20 {
+			spot := (start + i) % spots
+			coord := Coordinates{X: xMin + spot/rows, Y: player.Y*8 + yMin + spot%rows}
 			if !occupiedCoords[coord] {
 				occupiedCoords[coord] = true
+				playerCoords[player.Name] = coord
 				break
 			}
 		}
-		playerCoords[player.Name] = coord
 	}
 
-	// Place players on the ASCII art
-	for playerName, coord := range playerCoords {
+	// Place players on the ASCII art, in the same order, as names next to each other overlap
+	for _, player := range players {
+		playerName := player.Name
+		coord, ok := playerCoords[playerName]
+		if !ok {
+			continue
+		}
 		x, y := coord.X, coord.Y
 		// Ensure we don't go out of bounds in the art
 		if y < len(infernoArt) && x < len(infernoArt[y]) {
@@ -309,19 +554,73 @@ func (w *World) ToString() string {
 
 	// Create a list of player details
 	var playerList []string
-	for _, player := range w.Players {
+	for _, player := range s.Players {
 		playerList = append(playerList,
 			fmt.Sprintf("%s the level %d %s (%d)",
 				player.Name,
-				player.Stats.Level(),
+				player.Level,
 				player.Class,
-				player.ItemLevel()))
+				player.ItemLevel))
 	}
 
-	// Join the art and player list into a final output
-	return strings.Join(infernoArt, "\n") +
+	var monsterList []string
+	for _, monster := range s.Monsters {
+		monsterList = append(monsterList,
+			fmt.Sprintf("%s the level %d monster of %s (%d)",
+				monster.Name,
+				monster.Level,
+				CircleNames[monster.Y],
+				monster.ItemLevel))
+	}
+
+	// Join the art, terrain, player and monster lists into a final output
+	out := strings.Join(infernoArt, "\n") +
+		"\n\nTerrain:\n" + s.terrainArt() +
 		"\n\nSinners:\n" +
 		strings.Join(playerList, "\n")
+	if len(monsterList) > 0 {
+		out += "\n\nMonsters:\n" + strings.Join(monsterList, "\n")
+	}
+	return out
+}
+
+// terrainArt draws each circle's tiles, up to terrainArtWidth of them, with sinners shown
+// by the first letter of their name and monsters by a !.
+func (s *Snapshot) terrainArt() string {
+	occupants := make(map[Coordinates]rune, len(s.Players)+len(s.Monsters))
+	for _, player := range s.Players {
+		occupants[Coordinates{X: player.X, Y: player.Y}] = []rune(player.Name)[0]
+	}
+	for _, monster := range s.Monsters {
+		occupants[Coordinates{X: monster.X, Y: monster.Y}] = '!'
+	}
+
+	lines := make([]string, 0, WorldSize+1)
+	for circle := range WorldSize {
+		width := min(s.Widths[circle], terrainArtWidth)
+		row := make([]rune, width)
+		for x := range row {
+			c := Coordinates{X: x, Y: circle}
+			if occupant, ok := occupants[c]; ok {
+				row[x] = occupant
+			} else {
+				row[x] = s.worldMap.Tile(c).Symbol()
+			}
+		}
+		line := fmt.Sprintf("%-10s %s", CircleNames[circle], string(row))
+		if s.Widths[circle] > width {
+			line += fmt.Sprintf(" ... %d more", s.Widths[circle]-width)
+		}
+		lines = append(lines, line)
+	}
+
+	legend := make([]string, 0, len(Terrains)+3)
+	for _, t := range Terrains {
+		legend = append(legend, fmt.Sprintf("%c %s", t.Symbol, t.Name))
+	}
+	legend = append(legend, "+ a shrine", "1-9 a portal", "! a monster")
+	lines = append(lines, strings.Join(legend, ", "))
+	return strings.Join(lines, "\n")
 }
 
 func (w *World) getEmptyNeighborCoords(c *Coordinates) []Coordinates {
@@ -333,18 +632,11 @@ func (w *World) getOccupiedNeighborCoords(c *Coordinates) []Coordinates {
 }
 
 func (w *World) getNeighborCoords(c *Coordinates, lookingForPlayers bool) []Coordinates {
-	coords := make([]Coordinates, 0)
-	dirs := [...]Coordinates{Up, Down, Left, Right}
-	for _, dir := range dirs {
-		thisY := c.Y + dir.Y
-		thisX := c.X + dir.X
-		if thisY < WorldSize &&
-			thisY >= 0 &&
-			thisX < WorldSize &&
-			thisX >= 0 &&
-			((lookingForPlayers && w.Grid[thisY][thisX] != nil) ||
-				(!lookingForPlayers && w.Grid[thisY][thisX] == nil)) {
-			coords = append(coords, Coordinates{X: thisX, Y: thisY})
+	grid := w.grid()
+	coords := make([]Coordinates, 0, 4)
+	for _, n := range grid.neighbors(*c) {
+		if (grid.At(n) != nil) == lookingForPlayers {
+			coords = append(coords, n)
 		}
 	}
 	return coords
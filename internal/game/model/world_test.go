@@ -0,0 +1,234 @@
+package model_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+	"github.com/kvitebjorn/idleinferno/internal/game"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+func TestMain(m *testing.M) {
+	// Every event is logged, and there are a lot of them
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func newSinner(name string, x, y, itemLevel int) *model.Player {
+	p := &model.Player{
+		Name:     name,
+		Class:    "Shade",
+		Stats:    model.NewStats(),
+		Location: &model.Coordinates{X: x, Y: y},
+	}
+	if itemLevel > 0 {
+		p.Inventory[model.Weapon] = &model.Item{Name: "Blade", Class: model.Weapon, ItemLevel: itemLevel, Player: name}
+	}
+	return p
+}
+
+func newWorld(seed uint64) (*model.World, *game.Game, *clock.Fake) {
+	c := clock.NewFake(time.Unix(0, 0).UTC())
+	w := &model.World{Clock: c}
+	return w, game.New(w, rand.New(rand.NewPCG(seed, seed))), c
+}
+
+func TestLoginRejectsSomeoneAlreadyInTheWorld(t *testing.T) {
+	w, _, _ := newWorld(1)
+	if _, _, err := w.Login(newSinner("al", 0, 0, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := w.Login(newSinner("al", 3, 0, 0)); err == nil {
+		t.Fatal("the same name logged in twice")
+	}
+	if n := len(w.Players); n != 1 {
+		t.Fatalf("%d players in the world, want 1", n)
+	}
+}
+
+func TestLogoutOfSomeoneNotInTheWorld(t *testing.T) {
+	w, _, _ := newWorld(1)
+	al := newSinner("al", 0, 0, 0)
+
+	// Nobody's in the world yet
+	w.Logout(al)
+
+	if _, _, err := w.Login(al); err != nil {
+		t.Fatal(err)
+	}
+	w.Logout(al)
+	w.Logout(al)
+	if n := len(w.Players); n != 0 {
+		t.Fatalf("%d players in the world, want 0", n)
+	}
+
+	// Their cell was freed, so someone else can stand there
+	bob := newSinner("bob", 0, 0, 0)
+	if _, _, err := w.Login(bob); err != nil {
+		t.Fatal(err)
+	}
+	if *bob.Location != (model.Coordinates{X: 0, Y: 0}) {
+		t.Fatalf("bob stands at %v, want the cell al left", *bob.Location)
+	}
+}
+
+// TestConcurrentTicksLoginsAndReads is for the race detector: go test -race.
+func TestConcurrentTicksLoginsAndReads(t *testing.T) {
+	w, g, c := newWorld(7)
+	events := w.Subscribe(64)
+	go func() {
+		for range events {
+		}
+	}()
+
+	// A crowd that stays for the whole test, so there's always someone to fight
+	for i := range 30 {
+		if _, _, err := w.Login(newSinner(fmt.Sprintf("stayer%02d", i), i%9, i%model.WorldSize, 5+i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for range 200 {
+			c.Advance(game.TickInterval)
+			g.Step()
+		}
+	}()
+
+	for n := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				p := newSinner(fmt.Sprintf("visitor%d-%d", n, i%10), i%9, (n+i)%model.WorldSize, 1+i%20)
+				if _, _, err := w.Login(p); err != nil {
+					continue
+				}
+				w.SavePlayer(p.Name, func(*model.Player) {})
+				w.Logout(p)
+			}
+		}()
+	}
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				snapshot := w.Snapshot()
+				_ = snapshot.ToString()
+				if p := snapshot.Player("stayer00"); p != nil {
+					_ = p.ToString()
+				}
+				_ = w.ToString()
+				w.Publish()
+			}
+		}()
+	}
+
+	wg.Wait()
+	if n := len(w.Publish().Players); n != 30 {
+		t.Fatalf("%d players left in the world, want the 30 who stayed", n)
+	}
+}
+
+func TestSnapshotDrawsTheSameWorldTheSameWay(t *testing.T) {
+	w, _, _ := newWorld(1)
+	for i := range 12 {
+		if _, _, err := w.Login(newSinner(fmt.Sprintf("sinner%02d", i), i%9, i%3, i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first, second := w.Publish().ToString(), w.Publish().ToString()
+	if first != second {
+		t.Fatalf("the same world drew differently:\n%s\n\nthen:\n%s", first, second)
+	}
+}
+
+func TestHistoryKeepsEveryEventUntilSaved(t *testing.T) {
+	w, g, c := newWorld(3)
+	w.History = true
+	for i := range 30 {
+		if _, _, err := w.Login(newSinner(fmt.Sprintf("sinner%02d", i), i%9, i%model.WorldSize, 5+i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A subscriber that never reads falls behind at once, and one with room for everything counts them
+	_ = w.Subscribe(1)
+	all := w.Subscribe(1 << 16)
+
+	var saved []model.Event
+	failing := true
+	for range 100 {
+		c.Advance(game.TickInterval)
+		g.Step()
+		err := w.SaveEvents(func(events []model.Event) error {
+			if failing {
+				return errors.New("the database is down")
+			}
+			saved = append(saved, events...)
+			return nil
+		})
+		if failing && err == nil {
+			t.Fatal("a failed save wasn't reported")
+		}
+		failing = !failing
+	}
+
+	if len(saved) == 0 || len(saved) != len(all) {
+		t.Fatalf("saved %d events, want all %d", len(saved), len(all))
+	}
+	for i := 1; i < len(saved); i++ {
+		if saved[i].Time.Before(saved[i-1].Time) {
+			t.Fatalf("event %d at %v saved after one at %v", i, saved[i].Time, saved[i-1].Time)
+		}
+	}
+	if err := w.SaveEvents(func([]model.Event) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	w.SaveEvents(func(events []model.Event) error {
+		t.Fatalf("%d events saved twice", len(events))
+		return nil
+	})
+}
+
+func TestCatchUpWithoutAMaxUsesTheDefault(t *testing.T) {
+	w, _, c := newWorld(1)
+	w.CatchUp = &model.CatchUp{Share: 0.5, Tick: game.TickInterval}
+
+	al := newSinner("al", 0, 0, 0)
+	al.Stats.LastSeen = c.Now()
+	c.Advance(100 * time.Hour)
+
+	_, away, err := w.Login(al)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if away == nil || away.Credited != model.DefaultCatchUpMax {
+		t.Fatalf("away = %+v, want %s credited", away, model.DefaultCatchUpMax)
+	}
+}
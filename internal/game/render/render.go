@@ -0,0 +1,117 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+const (
+	Size      = 400
+	margin    = 10
+	dotRadius = 4
+)
+
+var (
+	background = color.RGBA{0x12, 0x06, 0x06, 0xff}
+	ringColor  = color.RGBA{0x8a, 0x2a, 0x0a, 0xff}
+	dotColor   = color.RGBA{0xff, 0xcf, 0x5c, 0xff}
+)
+
+func ringWidth() float64 {
+	return float64(Size/2-margin) / float64(model.WorldSize)
+}
+
+// ringRadius is the outer radius of a row of the grid.
+// Row 0, the first circle, is the outermost ring.
+func ringRadius(row int) float64 {
+	return float64(Size/2-margin) - float64(row)*ringWidth()
+}
+
+// position places a grid cell on the map: the row picks the ring,
+// and the column picks the angle around it, out of the width of the row.
+func position(x, y, width int) (float64, float64) {
+	radius := ringRadius(y) - ringWidth()/2
+	angle := float64(x) / float64(max(width, 1)) * 2 * math.Pi
+	return Size/2 + math.Cos(angle)*radius, Size/2 + math.Sin(angle)*radius
+}
+
+// sorted orders the players by name so the output doesn't depend on login order.
+func sorted(players []model.PlayerSnapshot) []model.PlayerSnapshot {
+	players = slices.Clone(players)
+	slices.SortFunc(players, func(a, b model.PlayerSnapshot) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return players
+}
+
+// SVG draws the world with every player at their real grid position.
+func SVG(snapshot *model.Snapshot) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		Size, Size, Size, Size)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", Size, Size, hex(background))
+
+	for row := 0; row < model.WorldSize; row++ {
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%.2f" fill="none" stroke="%s"><title>Circle %d: %s</title></circle>`+"\n",
+			Size/2, Size/2, ringRadius(row), hex(ringColor), row+1, model.CircleNames[row])
+	}
+
+	for _, p := range sorted(snapshot.Players) {
+		cx, cy := position(p.X, p.Y, snapshot.Widths[p.Y])
+		fmt.Fprintf(&b, `<circle cx="%.2f" cy="%.2f" r="%d" fill="%s"><title>%s the level %d %s (%d)</title></circle>`+"\n",
+			cx, cy, dotRadius, hex(dotColor), html.EscapeString(p.Name), p.Level, html.EscapeString(p.Class), p.ItemLevel)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.Bytes()
+}
+
+// PNG draws the same picture as SVG, without the tooltips.
+func PNG(snapshot *model.Snapshot) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, Size, Size))
+	center := float64(Size) / 2
+
+	for y := 0; y < Size; y++ {
+		for x := 0; x < Size; x++ {
+			img.SetRGBA(x, y, background)
+			dist := math.Hypot(float64(x)+0.5-center, float64(y)+0.5-center)
+			for row := 0; row < model.WorldSize; row++ {
+				if math.Abs(dist-ringRadius(row)) < 0.75 {
+					img.SetRGBA(x, y, ringColor)
+					break
+				}
+			}
+		}
+	}
+
+	for _, p := range sorted(snapshot.Players) {
+		cx, cy := position(p.X, p.Y, snapshot.Widths[p.Y])
+		for y := int(cy) - dotRadius; y <= int(cy)+dotRadius; y++ {
+			for x := int(cx) - dotRadius; x <= int(cx)+dotRadius; x++ {
+				if math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy) <= dotRadius {
+					img.SetRGBA(x, y, dotColor)
+				}
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	err := png.Encode(&b, img)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
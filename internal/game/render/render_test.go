@@ -0,0 +1,60 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// snapshot is a small world, with its players out of name order, and a name that needs escaping.
+func snapshot() *model.Snapshot {
+	s := &model.Snapshot{
+		Players: []model.PlayerSnapshot{
+			{Name: "virgil", Class: "Poet", Level: 40, ItemLevel: 120, X: 4, Y: 0},
+			{Name: "beatrice", Class: "Saint", Level: 99, ItemLevel: 300, X: 0, Y: 8},
+			{Name: "<al & bo>", Class: "Shade", Level: 3, ItemLevel: 7, X: 11, Y: 4},
+		},
+		Monsters: []model.PlayerSnapshot{
+			{Name: "a lost soul", Level: 5, ItemLevel: 10, X: 2, Y: 1},
+		},
+	}
+	for circle := range s.Widths {
+		s.Widths[circle] = model.DefaultCircleWidth + 2*circle
+	}
+	return s
+}
+
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to write it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s doesn't match %s, run go test -update if the change is meant", name, path)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	golden(t, "map.svg", SVG(snapshot()))
+}
+
+func TestPNG(t *testing.T) {
+	got, err := PNG(snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "map.png", got)
+}
@@ -0,0 +1,267 @@
+package sim
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+	"github.com/kvitebjorn/idleinferno/internal/game"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+const TicksPerDay = int(24 * time.Hour / game.TickInterval)
+
+const TargetLevel = 100
+
+type Config struct {
+	Players int
+	Ticks   int
+	Seed    uint64
+}
+
+type LevelPoint struct {
+	Tick     int
+	MinLevel int
+	AvgLevel float64
+	MaxLevel int
+}
+
+type Report struct {
+	Config Config
+
+	LevelCurve []LevelPoint
+	// Found item level -> how many were equipped
+	ItemLevels map[int]int
+
+	Fights int
+	// Fights won by whoever started them
+	ChallengerWins int
+	// Fights between unequal item levels, and how many the stronger side won
+	UnevenFights  int
+	FavouriteWins int
+	// Fights against monsters, which are left out of the numbers above, and how many the players won
+	MonsterFights int
+	MonsterWins   int
+
+	// Tick at which each player first reached TargetLevel
+	TargetLevelTicks []int
+
+	// How each class fared, by class name
+	Classes map[string]*ClassResult
+}
+
+type ClassResult struct {
+	Players    int
+	FinalLevel float64
+	Fights     int
+	Wins       int
+}
+
+// Run plays a game of virtual players as fast as it can, with no wall clock.
+// The same config always produces the same report.
+func Run(cfg Config) Report {
+	// The world logs everything it does, which we don't want to see here
+	logOutput := log.Writer()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(logOutput)
+
+	gameClock := clock.NewFake(time.Unix(0, 0).UTC())
+	// Every event counts, so take them from the world's history rather than a subscription that could fall behind
+	world := &model.World{Clock: gameClock, History: true}
+	g := game.New(world, rand.New(rand.NewPCG(cfg.Seed, cfg.Seed)))
+
+	for i := 0; i < cfg.Players; i++ {
+		player := &model.Player{
+			Id:       fmt.Sprintf("sim-%d", i),
+			Name:     fmt.Sprintf("sinner%03d", i),
+			Class:    model.Classes[i%len(model.Classes)].Name,
+			Stats:    model.NewStats(),
+			Location: &model.Coordinates{},
+		}
+		_, _, err := world.Login(player)
+		if err != nil {
+			// The world is full
+			break
+		}
+	}
+	cfg.Players = len(world.Players)
+
+	report := Report{
+		Config:     cfg,
+		ItemLevels: make(map[int]int),
+		Classes:    make(map[string]*ClassResult),
+	}
+	classes := make(map[string]string)
+	for _, p := range world.Players {
+		classes[p.Name] = p.Class
+		if report.Classes[p.Class] == nil {
+			report.Classes[p.Class] = &ClassResult{}
+		}
+		report.Classes[p.Class].Players++
+	}
+	reached := make(map[string]bool)
+	checkpoint := max(cfg.Ticks/20, 1)
+
+	for tick := 1; tick <= cfg.Ticks; tick++ {
+		gameClock.Advance(game.TickInterval)
+		g.Step()
+
+		itemLevels := make(map[string]int)
+		for _, p := range world.Players {
+			itemLevels[p.Name] = p.ItemLevel()
+		}
+
+		world.SaveEvents(func(events []model.Event) error {
+			for _, e := range events {
+				report.record(e, tick, itemLevels, classes, reached)
+			}
+			return nil
+		})
+
+		if tick%checkpoint == 0 || tick == cfg.Ticks {
+			report.LevelCurve = append(report.LevelCurve, levelPoint(tick, world.Players))
+		}
+	}
+
+	for _, p := range world.Players {
+		result := report.Classes[p.Class]
+		result.FinalLevel += float64(p.Stats.Level) / float64(result.Players)
+	}
+
+	return report
+}
+
+func (r *Report) record(e model.Event, tick int, itemLevels map[string]int, classes map[string]string, reached map[string]bool) {
+	switch e.Kind {
+	case model.ItemEvent:
+		r.ItemLevels[e.Level]++
+	case model.FightEvent:
+		// Only players start fights, so an opponent without a class is a monster
+		if _, ok := classes[e.Opponent]; !ok {
+			r.MonsterFights++
+			if e.Winner == e.Player {
+				r.MonsterWins++
+			}
+			return
+		}
+		r.Fights++
+		if e.Winner == e.Player {
+			r.ChallengerWins++
+		}
+		for _, name := range []string{e.Player, e.Opponent} {
+			result := r.Classes[classes[name]]
+			result.Fights++
+			if e.Winner == name {
+				result.Wins++
+			}
+		}
+		playerLevel, opponentLevel := itemLevels[e.Player], itemLevels[e.Opponent]
+		if playerLevel != opponentLevel {
+			r.UnevenFights++
+			favourite := e.Player
+			if opponentLevel > playerLevel {
+				favourite = e.Opponent
+			}
+			if e.Winner == favourite {
+				r.FavouriteWins++
+			}
+		}
+	case model.LevelUpEvent:
+		if e.Level >= TargetLevel && !reached[e.Player] {
+			reached[e.Player] = true
+			r.TargetLevelTicks = append(r.TargetLevelTicks, tick)
+		}
+	}
+}
+
+func levelPoint(tick int, players []*model.Player) LevelPoint {
+	point := LevelPoint{Tick: tick}
+	if len(players) == 0 {
+		return point
+	}
+
+	point.MinLevel = players[0].Stats.Level
+	sum := 0
+	for _, p := range players {
+		level := p.Stats.Level
+		point.MinLevel = min(point.MinLevel, level)
+		point.MaxLevel = max(point.MaxLevel, level)
+		sum += level
+	}
+	point.AvgLevel = float64(sum) / float64(len(players))
+	return point
+}
+
+func days(ticks int) float64 {
+	return float64(ticks) / float64(TicksPerDay)
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(total)
+}
+
+func (r Report) String() string {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 4, 1, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Simulated %d players for %d ticks (%.1f days), seed %d\n\n",
+		r.Config.Players, r.Config.Ticks, days(r.Config.Ticks), r.Config.Seed)
+
+	fmt.Fprintf(tw, "Level curve:\n")
+	fmt.Fprintf(tw, "day\tmin\tavg\tmax\n")
+	for _, p := range r.LevelCurve {
+		fmt.Fprintf(tw, "%.1f\t%d\t%.1f\t%d\n", days(p.Tick), p.MinLevel, p.AvgLevel, p.MaxLevel)
+	}
+
+	fmt.Fprintf(tw, "\nItem levels found:\n")
+	fmt.Fprintf(tw, "level\tcount\n")
+	levels := make([]int, 0, len(r.ItemLevels))
+	for level := range r.ItemLevels {
+		levels = append(levels, level)
+	}
+	slices.Sort(levels)
+	for _, level := range levels {
+		fmt.Fprintf(tw, "%d\t%d\n", level, r.ItemLevels[level])
+	}
+
+	fmt.Fprintf(tw, "\nFights: %d\n", r.Fights)
+	fmt.Fprintf(tw, "Challenger win rate: %.1f%%\n", percent(r.ChallengerWins, r.Fights))
+	fmt.Fprintf(tw, "Higher item level win rate: %.1f%% of %d uneven fights\n",
+		percent(r.FavouriteWins, r.UnevenFights), r.UnevenFights)
+	fmt.Fprintf(tw, "Monster fights: %d, won %.1f%%\n", r.MonsterFights, percent(r.MonsterWins, r.MonsterFights))
+
+	fmt.Fprintf(tw, "\nClasses:\n")
+	fmt.Fprintf(tw, "class\tplayers\tavg level\tfight win rate\n")
+	for _, c := range model.Classes {
+		result, ok := r.Classes[c.Name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%.1f%%\n",
+			c.Name, result.Players, result.FinalLevel, percent(result.Wins, result.Fights))
+	}
+
+	fmt.Fprintf(tw, "\nTime to level %d:\n", TargetLevel)
+	if len(r.TargetLevelTicks) == 0 {
+		fmt.Fprintf(tw, "Nobody got there.\n")
+	} else {
+		ticks := slices.Clone(r.TargetLevelTicks)
+		slices.Sort(ticks)
+		fmt.Fprintf(tw, "Reached by %d of %d players\n", len(ticks), r.Config.Players)
+		fmt.Fprintf(tw, "fastest\tmedian\tslowest\n")
+		fmt.Fprintf(tw, "%.1f days\t%.1f days\t%.1f days\n",
+			days(ticks[0]), days(ticks[len(ticks)/2]), days(ticks[len(ticks)-1]))
+	}
+
+	tw.Flush()
+	return sb.String()
+}
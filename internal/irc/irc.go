@@ -0,0 +1,332 @@
+package irc
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+// DefaultThrottle is the least time between two announcements in the channel, unless the gateway says otherwise
+const DefaultThrottle = 10 * time.Second
+
+// RelayEvents are the kinds of event announced in the channel, unless the gateway says otherwise
+var RelayEvents = []model.EventKind{model.LevelUpEvent, model.AchievementEvent, model.BossEvent, model.CraftEvent}
+
+// Handler is what the gateway drives when sinners come and go from the channel.
+type Handler interface {
+	Register(name, password, email, class string) error
+	Login(name, password string) error
+	// Rejoin puts an already authenticated player back in the world
+	Rejoin(name string) error
+	Logout(name string)
+}
+
+// Gateway keeps a single connection to an IRC server and mirrors the
+// channel membership into the game, like the IdleRPGs of old.
+type Gateway struct {
+	Server  string
+	Nick    string
+	Channel string
+	Handler Handler
+
+	// Events are the kinds of event Relay announces in the channel. When empty, RelayEvents.
+	Events []model.EventKind
+	// MinLevel leaves out the events below this level
+	MinLevel int
+	// Throttle is the least time between two announcements, so a busy world can't flood the channel.
+	// When zero, DefaultThrottle.
+	Throttle time.Duration
+	// Clock times the throttle. When nil, the wall clock is used.
+	Clock clock.Clock
+
+	conn net.Conn
+	// nick -> player name, for everyone who has logged in over IRC
+	sessions map[string]string
+	// nicks currently in the channel
+	present map[string]bool
+
+	mut sync.Mutex
+}
+
+type message struct {
+	nick    string
+	command string
+	params  []string
+}
+
+func parse(line string) message {
+	var msg message
+
+	if strings.HasPrefix(line, ":") {
+		prefix, rest, _ := strings.Cut(line[1:], " ")
+		msg.nick, _, _ = strings.Cut(prefix, "!")
+		line = rest
+	}
+
+	trailing := ""
+	hasTrailing := false
+	if i := strings.Index(line, " :"); i >= 0 {
+		trailing = line[i+2:]
+		hasTrailing = true
+		line = line[:i]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) > 0 {
+		msg.command = strings.ToUpper(fields[0])
+		msg.params = fields[1:]
+	}
+	if hasTrailing {
+		msg.params = append(msg.params, trailing)
+	}
+
+	return msg
+}
+
+// Run connects to the server and handles traffic until the connection drops.
+func (g *Gateway) Run() error {
+	conn, err := net.Dial("tcp", g.Server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	g.mut.Lock()
+	g.conn = conn
+	g.sessions = make(map[string]string)
+	g.present = make(map[string]bool)
+	g.mut.Unlock()
+
+	g.send("NICK %s", g.Nick)
+	g.send("USER %s 0 * :idleinferno", g.Nick)
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		g.handle(parse(strings.TrimRight(scanner.Text(), "\r")))
+	}
+
+	// Everybody we were tracking is gone with the connection
+	g.mut.Lock()
+	sessions := g.sessions
+	g.sessions = make(map[string]string)
+	g.conn = nil
+	g.mut.Unlock()
+	for _, name := range sessions {
+		g.Handler.Logout(name)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("IRC connection to %s closed.", g.Server)
+}
+
+// Say relays a message to the channel, one PRIVMSG per line.
+func (g *Gateway) Say(msg string) {
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		g.send("PRIVMSG %s :%s", g.Channel, line)
+	}
+}
+
+// Relay announces game events in the channel until the channel of events is closed.
+// Only the kinds in Events at MinLevel or above are announced, and no more than one each Throttle;
+// the ones that come too soon after are skipped, and counted in the next announcement.
+func (g *Gateway) Relay(events <-chan model.Event) {
+	kinds := g.Events
+	if len(kinds) == 0 {
+		kinds = RelayEvents
+	}
+	throttle := g.Throttle
+	if throttle == 0 {
+		throttle = DefaultThrottle
+	}
+	c := g.Clock
+	if c == nil {
+		c = clock.Real{}
+	}
+
+	var last time.Time
+	skipped := 0
+	for e := range events {
+		if !slices.Contains(kinds, e.Kind) || e.Level < g.MinLevel {
+			continue
+		}
+		now := c.Now()
+		if !last.IsZero() && now.Sub(last) < throttle {
+			skipped++
+			continue
+		}
+
+		msg := e.Message
+		if skipped > 0 {
+			msg += fmt.Sprintf(" (and %d more since)", skipped)
+		}
+		g.Say(msg)
+		last, skipped = now, 0
+	}
+}
+
+func (g *Gateway) notice(nick, msg string) {
+	g.send("NOTICE %s :%s", nick, msg)
+}
+
+func (g *Gateway) send(format string, args ...any) {
+	g.mut.Lock()
+	defer g.mut.Unlock()
+
+	if g.conn == nil {
+		return
+	}
+	_, err := fmt.Fprintf(g.conn, format+"\r\n", args...)
+	if err != nil {
+		log.Println("Failed to write to IRC:", err.Error())
+	}
+}
+
+func (g *Gateway) handle(msg message) {
+	switch msg.command {
+	case "PING":
+		g.send("PONG :%s", strings.Join(msg.params, " "))
+	case "001":
+		g.send("JOIN %s", g.Channel)
+	case "353":
+		// NAMES reply: <me> <type> <channel> :<nicks>
+		if len(msg.params) < 4 {
+			return
+		}
+		for _, nick := range strings.Fields(msg.params[3]) {
+			g.setPresent(strings.TrimLeft(nick, "~&@%+"), true)
+		}
+	case "JOIN":
+		g.setPresent(msg.nick, true)
+		g.mut.Lock()
+		name, ok := g.sessions[msg.nick]
+		g.mut.Unlock()
+		if ok {
+			g.rejoin(msg.nick, name)
+		}
+	case "PART":
+		g.leave(msg.nick, false)
+	case "KICK":
+		if len(msg.params) >= 2 {
+			g.leave(msg.params[1], true)
+		}
+	case "QUIT":
+		g.leave(msg.nick, true)
+	case "NICK":
+		if len(msg.params) < 1 {
+			return
+		}
+		// Changing nicks drops you out of the game, you'll have to LOGIN again
+		g.leave(msg.nick, true)
+		g.setPresent(msg.params[0], true)
+	case "PRIVMSG":
+		if len(msg.params) < 2 || !strings.EqualFold(msg.params[0], g.Nick) {
+			return
+		}
+		g.command(msg.nick, strings.Fields(msg.params[1]))
+	}
+}
+
+func (g *Gateway) command(nick string, args []string) {
+	if len(args) == 0 {
+		return
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "REGISTER":
+		if len(args) != 5 {
+			g.notice(nick, "Usage: REGISTER <name> <password> <email> <class>")
+			return
+		}
+		err := g.Handler.Register(args[1], args[2], args[3], args[4])
+		if err != nil {
+			g.notice(nick, err.Error())
+			return
+		}
+		g.notice(nick, fmt.Sprintf("Welcome to the inferno, %s. LOGIN to begin idling.", args[1]))
+	case "LOGIN":
+		if len(args) != 3 {
+			g.notice(nick, "Usage: LOGIN <name> <password>")
+			return
+		}
+		g.login(nick, args[1], args[2])
+	case "LOGOUT":
+		g.leave(nick, true)
+	default:
+		g.notice(nick, "Invalid request, sinner.")
+	}
+}
+
+func (g *Gateway) login(nick, name, password string) {
+	g.mut.Lock()
+	inChannel := g.present[nick]
+	_, alreadyIn := g.sessions[nick]
+	g.mut.Unlock()
+
+	if !inChannel {
+		g.notice(nick, fmt.Sprintf("You must be in %s to idle.", g.Channel))
+		return
+	}
+	if alreadyIn {
+		g.notice(nick, "You are already logged in.")
+		return
+	}
+
+	err := g.Handler.Login(name, password)
+	if err != nil {
+		g.notice(nick, err.Error())
+		return
+	}
+
+	g.mut.Lock()
+	g.sessions[nick] = name
+	g.mut.Unlock()
+	g.notice(nick, fmt.Sprintf("Logged in as %s.", name))
+}
+
+// rejoin puts a player back in the world after they return to the channel.
+// Their credentials were already checked when they first logged in.
+func (g *Gateway) rejoin(nick, name string) {
+	err := g.Handler.Rejoin(name)
+	if err != nil {
+		g.mut.Lock()
+		delete(g.sessions, nick)
+		g.mut.Unlock()
+		g.notice(nick, err.Error())
+	}
+}
+
+// leave logs the nick's player out of the world.
+// When forget is set the session is dropped, and they have to LOGIN again.
+func (g *Gateway) leave(nick string, forget bool) {
+	g.mut.Lock()
+	delete(g.present, nick)
+	name, ok := g.sessions[nick]
+	if forget {
+		delete(g.sessions, nick)
+	}
+	g.mut.Unlock()
+
+	if ok {
+		g.Handler.Logout(name)
+	}
+}
+
+func (g *Gateway) setPresent(nick string, present bool) {
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	g.present[nick] = present
+}
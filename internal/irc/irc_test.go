@@ -0,0 +1,189 @@
+package irc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+// fakeServer is an IRC server that takes a single connection, from the gateway under test.
+type fakeServer struct {
+	t     *testing.T
+	conn  net.Conn
+	lines chan string
+}
+
+func serve(t *testing.T, g *Gateway) *fakeServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	g.Server = ln.Addr().String()
+
+	ran := make(chan error, 1)
+	go func() { ran <- g.Run() }()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &fakeServer{t: t, conn: conn, lines: make(chan string, 64)}
+	go func() {
+		defer close(srv.lines)
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			srv.lines <- scanner.Text()
+		}
+	}()
+	t.Cleanup(func() {
+		conn.Close()
+		<-ran
+	})
+
+	srv.expect("NICK " + g.Nick)
+	srv.expect("USER " + g.Nick + " 0 * :idleinferno")
+	return srv
+}
+
+func (s *fakeServer) send(format string, args ...any) {
+	s.t.Helper()
+	if _, err := fmt.Fprintf(s.conn, format+"\r\n", args...); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// expect waits for the gateway to send the line, failing on anything else first.
+func (s *fakeServer) expect(want string) {
+	s.t.Helper()
+	select {
+	case got, ok := <-s.lines:
+		if !ok {
+			s.t.Fatalf("connection closed, want %q", want)
+		}
+		if got != want {
+			s.t.Fatalf("got %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		s.t.Fatalf("nothing sent, want %q", want)
+	}
+}
+
+// quiet checks the gateway has nothing more to say for now.
+func (s *fakeServer) quiet() {
+	s.t.Helper()
+	select {
+	case got := <-s.lines:
+		s.t.Fatalf("unexpected %q", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+type fakeHandler struct {
+	mut      sync.Mutex
+	password string
+	online   []string
+}
+
+func (h *fakeHandler) Register(name, password, email, class string) error {
+	return errors.New("Not here.")
+}
+
+func (h *fakeHandler) Login(name, password string) error {
+	if password != h.password {
+		return fmt.Errorf("Invalid user credentials for %s", name)
+	}
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	h.online = append(h.online, name)
+	return nil
+}
+
+func (h *fakeHandler) Rejoin(name string) error {
+	return h.Login(name, h.password)
+}
+
+func (h *fakeHandler) Logout(name string) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	for i, online := range h.online {
+		if online == name {
+			h.online = append(h.online[:i], h.online[i+1:]...)
+			return
+		}
+	}
+}
+
+func (h *fakeHandler) Online() string {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	return strings.Join(h.online, ",")
+}
+
+func TestGatewayMirrorsTheChannel(t *testing.T) {
+	h := &fakeHandler{password: "hunter2"}
+	g := &Gateway{Nick: "DANTE", Channel: "#inferno", Handler: h}
+	srv := serve(t, g)
+
+	srv.send(":irc.test 001 DANTE :Welcome")
+	srv.expect("JOIN #inferno")
+	srv.send("PING :irc.test")
+	srv.expect("PONG :irc.test")
+
+	srv.send(":al!al@host PRIVMSG DANTE :LOGIN al hunter2")
+	srv.expect("NOTICE al :You must be in #inferno to idle.")
+
+	srv.send(":al!al@host JOIN #inferno")
+	srv.send(":al!al@host PRIVMSG DANTE :LOGIN al wrong")
+	srv.expect("NOTICE al :Invalid user credentials for al")
+	srv.send(":al!al@host PRIVMSG DANTE :LOGIN al hunter2")
+	srv.expect("NOTICE al :Logged in as al.")
+	if online := h.Online(); online != "al" {
+		t.Fatalf("online: %q, want al", online)
+	}
+
+	// Parting keeps the session, so coming back puts them straight back in
+	srv.send(":al!al@host PART #inferno")
+	srv.send(":al!al@host JOIN #inferno")
+	srv.send(":al!al@host QUIT :bye")
+	srv.send("PING :sync")
+	srv.expect("PONG :sync")
+	if online := h.Online(); online != "" {
+		t.Fatalf("online after quitting: %q, want nobody", online)
+	}
+}
+
+func TestRelayFiltersAndThrottlesEvents(t *testing.T) {
+	c := clock.NewFake(time.Unix(0, 0))
+	g := &Gateway{Nick: "DANTE", Channel: "#inferno", Handler: &fakeHandler{}, MinLevel: 10, Throttle: time.Minute, Clock: c}
+	srv := serve(t, g)
+
+	events := make(chan model.Event)
+	go g.Relay(events)
+	defer close(events)
+
+	events <- model.Event{Kind: model.FightEvent, Level: 50, Message: "al won a fight"}
+	events <- model.Event{Kind: model.LevelUpEvent, Level: 5, Message: "al reached level 5"}
+	events <- model.Event{Kind: model.LevelUpEvent, Level: 12, Message: "al reached level 12"}
+	srv.expect("PRIVMSG #inferno :al reached level 12")
+
+	c.Advance(30 * time.Second)
+	events <- model.Event{Kind: model.LevelUpEvent, Level: 20, Message: "bo reached level 20"}
+	events <- model.Event{Kind: model.BossEvent, Level: 30, Message: "Charon fell"}
+	// Once this is taken, the one before is done with, before the clock moves on
+	events <- model.Event{Kind: model.FightEvent, Level: 50, Message: "bo won a fight"}
+	srv.quiet()
+
+	c.Advance(30 * time.Second)
+	events <- model.Event{Kind: model.AchievementEvent, Level: 10, Message: "bo earned Pilgrim"}
+	srv.expect("PRIVMSG #inferno :bo earned Pilgrim (and 2 more since)")
+}
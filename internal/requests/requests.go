@@ -10,15 +10,23 @@ const (
 )
 
 type Player struct {
-	Name      string
-	Class     string
-	Xp        uint64
-	Level     int
-	ItemLevel int
-	X         int
-	Y         int
-	Online    bool
-	Created   string
+	Name  string
+	Title string
+	Class string
+	Level int
+	// TimeToLevel is in seconds, NextLevel is the same written out e.g. "2 days, 04:13:22"
+	TimeToLevel int64
+	NextLevel   string
+	// ItemLevel includes set bonuses, BaseItemLevel doesn't
+	ItemLevel     int
+	BaseItemLevel int
+	Sets          []string
+	Stash         []string
+	X             int
+	Y             int
+	Online        bool
+	Created       string
+	Achievements  []string
 }
 
 type User struct {
@@ -0,0 +1,180 @@
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+)
+
+type message struct {
+	id    uint64
+	event string
+	data  []byte
+}
+
+// Broker fans server-sent events out to every connected client.
+// Published events are kept in a ring buffer so clients can resume with Last-Event-ID.
+type Broker struct {
+	ring   []message
+	next   int
+	full   bool
+	lastId uint64
+
+	// The latest un-numbered message of each kind, sent to clients as they connect
+	latest map[string]message
+
+	clients map[chan message]struct{}
+	mut     sync.Mutex
+
+	// Clock paces the heartbeats. When nil, the wall clock is used.
+	Clock clock.Clock
+}
+
+func NewBroker(size int) *Broker {
+	return &Broker{
+		ring:    make([]message, size),
+		latest:  make(map[string]message),
+		clients: make(map[chan message]struct{}),
+	}
+}
+
+// Publish sends v to everyone and remembers it for resuming clients.
+func (b *Broker) Publish(event string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fmt.Println("Failed to encode event:", err.Error())
+		return
+	}
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	b.lastId++
+	msg := message{id: b.lastId, event: event, data: data}
+	b.ring[b.next] = msg
+	b.next = (b.next + 1) % len(b.ring)
+	if b.next == 0 {
+		b.full = true
+	}
+	b.fanOut(msg)
+}
+
+// Send sends v to everyone without an id, replacing the previous value of this
+// event kind. It is meant for state like world snapshots, which are not worth resuming.
+func (b *Broker) Send(event string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fmt.Println("Failed to encode event:", err.Error())
+		return
+	}
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	msg := message{event: event, data: data}
+	b.latest[event] = msg
+	b.fanOut(msg)
+}
+
+func (b *Broker) fanOut(msg message) {
+	for ch := range b.clients {
+		select {
+		case ch <- msg:
+		default:
+			// Too slow, they can reconnect and resume
+			close(ch)
+			delete(b.clients, ch)
+		}
+	}
+}
+
+// since returns the buffered messages after lastId, oldest first.
+func (b *Broker) since(lastId uint64) []message {
+	msgs := make([]message, 0)
+	if b.full {
+		msgs = append(msgs, b.ring[b.next:]...)
+	}
+	msgs = append(msgs, b.ring[:b.next]...)
+
+	for i, msg := range msgs {
+		if msg.id > lastId {
+			return msgs[i:]
+		}
+	}
+	return nil
+}
+
+func (b *Broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := make(chan message, 64)
+
+	b.mut.Lock()
+	var backlog []message
+	lastId, err := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+	if err == nil {
+		backlog = b.since(lastId)
+	}
+	for _, msg := range b.latest {
+		backlog = append(backlog, msg)
+	}
+	b.clients[ch] = struct{}{}
+	b.mut.Unlock()
+
+	defer func() {
+		b.mut.Lock()
+		if _, ok := b.clients[ch]; ok {
+			close(ch)
+			delete(b.clients, ch)
+		}
+		b.mut.Unlock()
+	}()
+
+	for _, msg := range backlog {
+		write(w, msg)
+	}
+	flusher.Flush()
+
+	c := b.Clock
+	if c == nil {
+		c = clock.Real{}
+	}
+	heartbeat := c.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			write(w, msg)
+			flusher.Flush()
+		case <-heartbeat.C():
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func write(w http.ResponseWriter, msg message) {
+	if msg.id != 0 {
+		fmt.Fprintf(w, "id: %d\n", msg.id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.event, msg.data)
+}
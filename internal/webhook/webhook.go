@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+	"github.com/kvitebjorn/idleinferno/internal/config"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+const SignatureHeader = "X-Idleinferno-Signature"
+
+type delivery struct {
+	hook config.Webhook
+	body []byte
+}
+
+// Dispatcher posts game events to the configured webhooks.
+// Failed deliveries are retried with exponential backoff, each on its own,
+// so a hook that's down doesn't hold up the others.
+type Dispatcher struct {
+	Hooks  []config.Webhook
+	Client *http.Client
+
+	// Attempts is how many times a delivery is tried before giving up
+	Attempts int
+	// Backoff is the wait before the first retry, it doubles after each failure
+	Backoff time.Duration
+	// InFlight is how many deliveries, waiting to retry or not, can be under way at once
+	InFlight int
+	// Clock times the retries. When nil, the wall clock is used.
+	Clock clock.Clock
+}
+
+func NewDispatcher(hooks []config.Webhook) *Dispatcher {
+	return &Dispatcher{
+		Hooks:    hooks,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+		Attempts: 5,
+		Backoff:  time.Second,
+		InFlight: 64,
+	}
+}
+
+func (d *Dispatcher) clock() clock.Clock {
+	if d.Clock == nil {
+		return clock.Real{}
+	}
+	return d.Clock
+}
+
+// Run delivers events until the channel is closed.
+func (d *Dispatcher) Run(events <-chan model.Event) {
+	queue := make(chan delivery, 256)
+	defer close(queue)
+	go d.work(queue)
+
+	for e := range events {
+		body, err := json.Marshal(e)
+		if err != nil {
+			fmt.Println("Failed to encode webhook event:", err.Error())
+			continue
+		}
+		for _, hook := range d.Hooks {
+			if !Matches(hook, e) {
+				continue
+			}
+			select {
+			case queue <- delivery{hook, body}:
+			default:
+				fmt.Println("Webhook queue full, dropping event for", hook.URL)
+			}
+		}
+	}
+}
+
+// work starts each delivery as it comes off the queue, as long as there's room for another in flight.
+func (d *Dispatcher) work(queue <-chan delivery) {
+	slots := make(chan struct{}, max(d.InFlight, 1))
+	for del := range queue {
+		slots <- struct{}{}
+		go func() {
+			defer func() { <-slots }()
+			d.deliver(del)
+		}()
+	}
+}
+
+func (d *Dispatcher) deliver(del delivery) {
+	backoff := d.Backoff
+	for attempt := 1; ; attempt++ {
+		err := d.post(del)
+		if err == nil {
+			return
+		}
+		if attempt >= d.Attempts {
+			fmt.Println("Giving up on webhook", del.hook.URL+":", err.Error())
+			return
+		}
+		<-d.clock().After(backoff)
+		backoff *= 2
+	}
+}
+
+func (d *Dispatcher) post(del delivery) error {
+	req, err := http.NewRequest(http.MethodPost, del.hook.URL, bytes.NewReader(del.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if del.hook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(del.hook.Secret, del.body))
+	}
+
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// Matches reports whether the hook wants to hear about this event.
+func Matches(hook config.Webhook, e model.Event) bool {
+	if len(hook.Events) > 0 && !slices.Contains(hook.Events, string(e.Kind)) {
+		return false
+	}
+	return e.Level >= hook.MinLevel
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by
+// the hex HMAC-SHA256 of the body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kvitebjorn/idleinferno/internal/clock"
+	"github.com/kvitebjorn/idleinferno/internal/config"
+	"github.com/kvitebjorn/idleinferno/internal/game/model"
+)
+
+type received struct {
+	event     model.Event
+	signature string
+	body      []byte
+}
+
+// receiver is a webhook endpoint that fails the first failures requests, then hands on whatever it's sent.
+func receiver(t *testing.T, failures int32) (*httptest.Server, <-chan received, *atomic.Int32) {
+	t.Helper()
+	got := make(chan received, 16)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var e model.Event
+		if err := json.Unmarshal(body, &e); err != nil {
+			t.Errorf("bad body %q: %v", body, err)
+		}
+		got <- received{event: e, signature: r.Header.Get(SignatureHeader), body: body}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got, &calls
+}
+
+func wait(t *testing.T, got <-chan received) received {
+	t.Helper()
+	select {
+	case r := <-got:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("no webhook delivered")
+		return received{}
+	}
+}
+
+func TestDeliversMatchingEventsSigned(t *testing.T) {
+	srv, got, _ := receiver(t, 0)
+	d := NewDispatcher([]config.Webhook{{
+		URL:      srv.URL,
+		Events:   []string{string(model.LevelUpEvent)},
+		MinLevel: 10,
+		Secret:   "hunter2",
+	}})
+
+	events := make(chan model.Event, 3)
+	events <- model.Event{Kind: model.FightEvent, Level: 50, Message: "wrong kind"}
+	events <- model.Event{Kind: model.LevelUpEvent, Level: 5, Message: "too low"}
+	events <- model.Event{Kind: model.LevelUpEvent, Level: 12, Message: "al reached 12"}
+	close(events)
+	go d.Run(events)
+
+	r := wait(t, got)
+	if r.event.Message != "al reached 12" {
+		t.Fatalf("delivered %q, want only the matching level up", r.event.Message)
+	}
+	if want := Sign("hunter2", r.body); r.signature != want {
+		t.Fatalf("signature %q, want %q", r.signature, want)
+	}
+	select {
+	case extra := <-got:
+		t.Fatalf("unexpected delivery %q", extra.event.Message)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRetriesFailedDeliveries(t *testing.T) {
+	srv, got, calls := receiver(t, 2)
+	d := NewDispatcher([]config.Webhook{{URL: srv.URL}})
+	d.Backoff = time.Millisecond
+
+	events := make(chan model.Event, 1)
+	events <- model.Event{Kind: model.ItemEvent, Message: "found a sword"}
+	close(events)
+	go d.Run(events)
+
+	if r := wait(t, got); r.event.Message != "found a sword" {
+		t.Fatalf("delivered %q", r.event.Message)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("%d attempts, want 3", n)
+	}
+}
+
+func TestFailingHookDoesNotHoldUpOthers(t *testing.T) {
+	down, _, downCalls := receiver(t, 1<<30)
+	up, got, _ := receiver(t, 0)
+	d := NewDispatcher([]config.Webhook{{URL: down.URL}, {URL: up.URL}})
+	// The clock never moves, so the failed deliveries wait to retry for good
+	d.Clock = clock.NewFake(time.Unix(0, 0))
+
+	events := make(chan model.Event, 3)
+	for _, msg := range []string{"one", "two", "three"} {
+		events <- model.Event{Kind: model.FightEvent, Message: msg}
+	}
+	close(events)
+	go d.Run(events)
+
+	seen := map[string]bool{}
+	for range 3 {
+		seen[wait(t, got).event.Message] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("delivered %v, want all three", seen)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for downCalls.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := downCalls.Load(); n != 3 {
+		t.Fatalf("the failing hook was tried %d times, want once per event", n)
+	}
+}